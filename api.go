@@ -0,0 +1,55 @@
+package hellosign
+
+import (
+	"context"
+	"net/http"
+	"os"
+)
+
+// SignatureRequestCreator creates signature requests.
+type SignatureRequestCreator interface {
+	CreateSignatureRequest(request CreationRequest) (*SignatureRequest, error)
+	CreateEmbeddedSignatureRequest(request CreationRequest) (*SignatureRequest, error)
+	CreateSignatureRequestContext(ctx context.Context, request CreationRequest) (*SignatureRequest, error)
+	CreateEmbeddedSignatureRequestContext(ctx context.Context, request CreationRequest) (*SignatureRequest, error)
+}
+
+// SignatureRequestGetter reads signature requests.
+type SignatureRequestGetter interface {
+	GetSignatureRequest(signatureRequestID string) (*SignatureRequest, error)
+	ListSignatureRequests() (*ListResponse, error)
+	ListSignatureRequestsWithOptions(opts ListOptions) (*ListResponse, error)
+}
+
+// SignatureRequestUpdater changes, reminds or cancels existing signature requests.
+type SignatureRequestUpdater interface {
+	UpdateSignatureRequest(signatureRequestID string, signatureID string, email string) (*SignatureRequest, error)
+	RemindSignatureRequest(signatureRequestID string, email string) (*SignatureRequest, error)
+	CancelSignatureRequest(signatureRequestID string) (*http.Response, error)
+}
+
+// FileDownloader downloads the documents of a signature request.
+type FileDownloader interface {
+	GetPDF(signatureRequestID string) ([]byte, error)
+	GetFiles(signatureRequestID, fileType string) ([]byte, error)
+	SaveFile(signatureRequestID, fileType, destFilePath string) (os.FileInfo, error)
+	GetDocuments(ctx context.Context, signatureRequestID string, uploads ...string) ([]Document, error)
+}
+
+// EmbeddedSigner retrieves embedded signing URLs.
+type EmbeddedSigner interface {
+	GetEmbeddedSignURL(signatureID string) (*SignURLResponse, error)
+}
+
+// API is the set of HelloSign operations implemented by Client. Depend on it,
+// or on one of the smaller interfaces it embeds, to substitute a fake such as
+// hellosigntest.Mock in tests.
+type API interface {
+	SignatureRequestCreator
+	SignatureRequestGetter
+	SignatureRequestUpdater
+	FileDownloader
+	EmbeddedSigner
+}
+
+var _ API = (*Client)(nil)
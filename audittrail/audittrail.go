@@ -0,0 +1,284 @@
+// Package audittrail reads the audit trail (the signing certificate) HelloSign
+// appends to the merged PDF of a signature request.
+//
+//	trail, err := audittrail.Get(client, signatureRequestID)
+//
+// The parser was written against a reconstructed sample, not a certificate
+// downloaded from HelloSign, so its layout assumptions are unverified:
+//
+//   - the first audit trail page has a line "Audit trail" within its first
+//     three lines;
+//   - the details and each history row are laid out in tab separated columns,
+//     e.g. "Title\tOffer letter" and "SIGNED\t09 / 19 / 2014\tSigned by ...",
+//     followed by lines holding the time in UTC and "IP: ...";
+//   - dates are MM / DD / YYYY unless a line "Audit trail date format\tDD / MM / YYYY"
+//     says otherwise.
+//
+// Check the results against a real certificate before relying on them.
+package audittrail
+
+import (
+	"errors"
+	"regexp"
+	"strings"
+	"time"
+
+	hellosign "github.com/jheth/hellosign-go-sdk"
+)
+
+// ErrNotFound is returned when a PDF has no audit trail pages.
+var ErrNotFound = errors.New("audittrail: no audit trail found in PDF")
+
+// Action is the kind of an audit trail event, as printed in its first column.
+type Action string
+
+// Audit trail actions.
+const (
+	Sent      Action = "SENT"
+	Viewed    Action = "VIEWED"
+	Signed    Action = "SIGNED"
+	Declined  Action = "DECLINED"
+	Completed Action = "COMPLETED"
+)
+
+// Trail is the signing certificate HelloSign appends to the merged PDF
+// of a signature request.
+type Trail struct {
+	Title      string
+	FileName   string
+	DocumentID string
+	Status     string
+	Events     []Event // In the order printed, oldest first.
+}
+
+// Event is a row of the audit trail's document history.
+type Event struct {
+	Action      Action
+	Time        time.Time
+	Description string // e.g. "Signed by Jane Doe (jane@example.com)".
+	Name        string // The first person named in Description, if any.
+	Email       string
+	IP          string
+}
+
+// Signer summarizes the events of one signer.
+type Signer struct {
+	Name     string
+	Email    string
+	IP       string     // Address the signer signed or declined from, or last viewed from.
+	ViewedAt *time.Time // First view.
+	SignedAt *time.Time
+}
+
+// Signers returns the people who viewed, signed or declined, by email address
+// in order of first appearance.
+func (a *Trail) Signers() []Signer {
+	var signers []Signer
+	index := map[string]int{}
+	for _, event := range a.Events {
+		if event.Email == "" || event.Action == Sent {
+			continue
+		}
+		key := strings.ToLower(event.Email)
+		i, ok := index[key]
+		if !ok {
+			i = len(signers)
+			index[key] = i
+			signers = append(signers, Signer{Name: event.Name, Email: event.Email})
+		}
+
+		signer := &signers[i]
+		at := event.Time
+		switch event.Action {
+		case Viewed:
+			if signer.ViewedAt == nil {
+				signer.ViewedAt = &at
+			}
+			if signer.SignedAt == nil && event.IP != "" {
+				signer.IP = event.IP
+			}
+		case Signed, Declined:
+			if event.Action == Signed {
+				signer.SignedAt = &at
+			}
+			if event.IP != "" {
+				signer.IP = event.IP
+			}
+		}
+	}
+	return signers
+}
+
+// Split - Splits a merged PDF, as returned by GetPDF, into the
+// signed document and the audit trail pages HelloSign appends to it.
+func Split(pdf []byte) (document, auditTrail []byte, err error) {
+	f, pages, start, err := findAuditTrail(pdf)
+	if err != nil {
+		return nil, nil, err
+	}
+	return f.writePages(pages[:start]), f.writePages(pages[start:]), nil
+}
+
+// Parse - Reads the audit trail of a merged PDF, or of the audit
+// trail pages alone.
+func Parse(pdf []byte) (*Trail, error) {
+	f, pages, start, err := findAuditTrail(pdf)
+	if err != nil {
+		return nil, err
+	}
+
+	var lines []string
+	for _, page := range pages[start:] {
+		pageLines, err := f.pageLines(page)
+		if err != nil {
+			return nil, err
+		}
+		lines = append(lines, pageLines...)
+	}
+	return parseAuditLines(lines), nil
+}
+
+// GetPDF - Downloads the merged PDF of a signature request and returns only
+// its audit trail pages.
+func GetPDF(files hellosign.FileDownloader, signatureRequestID string) ([]byte, error) {
+	pdf, err := files.GetPDF(signatureRequestID)
+	if err != nil {
+		return nil, err
+	}
+	_, auditTrail, err := Split(pdf)
+	return auditTrail, err
+}
+
+// Get - Downloads the merged PDF of a signature request and parses its audit trail.
+func Get(files hellosign.FileDownloader, signatureRequestID string) (*Trail, error) {
+	pdf, err := files.GetPDF(signatureRequestID)
+	if err != nil {
+		return nil, err
+	}
+	return Parse(pdf)
+}
+
+// findAuditTrail returns the pages of pdf and the index of the first audit
+// trail page. HelloSign places the audit trail last, headed "Audit trail".
+func findAuditTrail(pdf []byte) (*pdfFile, []pdfPage, int, error) {
+	f, err := parsePDF(pdf)
+	if err != nil {
+		return nil, nil, 0, err
+	}
+	pages, err := f.pages()
+	if err != nil {
+		return nil, nil, 0, err
+	}
+
+	for i, page := range pages {
+		lines, err := f.pageLines(page)
+		if err != nil {
+			// Pages we can't read are not HelloSign's audit trail.
+			continue
+		}
+		for j, line := range lines {
+			if j < 3 && strings.EqualFold(strings.TrimSpace(line), "audit trail") {
+				return f, pages, i, nil
+			}
+		}
+	}
+	return nil, nil, 0, ErrNotFound
+}
+
+var (
+	auditDate   = regexp.MustCompile(`^(\d{1,2}) ?/ ?(\d{1,2}) ?/ ?(\d{4})$`)
+	auditClock  = regexp.MustCompile(`^\d{1,2}:\d{2}(:\d{2})? UTC$`)
+	auditAction = regexp.MustCompile(`^[A-Z][A-Z ]+$`)
+	auditPerson = regexp.MustCompile(`(?:to|by)\s+(.+?)\s*\(([^()\s]+@[^()\s]+)\)`)
+)
+
+// auditFields are the details printed above the document history.
+var auditFields = map[string]func(a *Trail) *string{
+	"title":       func(a *Trail) *string { return &a.Title },
+	"file name":   func(a *Trail) *string { return &a.FileName },
+	"document id": func(a *Trail) *string { return &a.DocumentID },
+	"status":      func(a *Trail) *string { return &a.Status },
+}
+
+// parseAuditLines reads the tab separated lines of the audit trail pages.
+// An event starts with a line "ACTION\tdate\tdescription", followed by lines
+// holding its time, the rest of a long description and the IP address.
+func parseAuditLines(lines []string) *Trail {
+	a := &Trail{}
+	dayFirst := false
+
+	type pending struct {
+		event  Event
+		date   []string
+		clock  string
+		closed bool // Lines after the IP address or a bare time belong to no event.
+	}
+	var events []*pending
+	var current *pending
+
+	for _, line := range lines {
+		cells := strings.Split(line, "\t")
+		key := strings.ToLower(cells[0])
+
+		if len(cells) == 2 {
+			if field, ok := auditFields[key]; ok {
+				if value := field(a); *value == "" {
+					*value = cells[1]
+				}
+				continue
+			}
+			if key == "audit trail date format" {
+				dayFirst = strings.HasPrefix(strings.ToUpper(cells[1]), "DD")
+				continue
+			}
+		}
+
+		first := false
+		if len(cells) >= 2 && auditAction.MatchString(cells[0]) {
+			if date := auditDate.FindStringSubmatch(cells[1]); date != nil {
+				current = &pending{event: Event{Action: Action(cells[0])}, date: date[1:]}
+				events = append(events, current)
+				cells, first = cells[2:], true
+			}
+		}
+		if current == nil || current.closed {
+			continue
+		}
+
+		text := false
+		for _, cell := range cells {
+			switch {
+			case auditClock.MatchString(cell) && current.clock == "":
+				current.clock = cell
+			case strings.HasPrefix(cell, "IP:"):
+				current.event.IP = strings.TrimSpace(strings.TrimPrefix(cell, "IP:"))
+				current.closed = true
+			default:
+				current.event.Description = strings.TrimSpace(current.event.Description + " " + cell)
+				text = true
+			}
+		}
+		if !first && !text {
+			current.closed = true
+		}
+	}
+
+	for _, p := range events {
+		month, day := p.date[0], p.date[1]
+		if dayFirst {
+			month, day = day, month
+		}
+		clock := strings.TrimSuffix(p.clock, " UTC")
+		if strings.Count(clock, ":") == 1 {
+			clock += ":00"
+		}
+		if t, err := time.Parse("1/2/2006 15:04:05", month+"/"+day+"/"+p.date[2]+" "+clock); err == nil {
+			p.event.Time = t
+		}
+		if person := auditPerson.FindStringSubmatch(p.event.Description); person != nil {
+			p.event.Name, p.event.Email = person[1], person[2]
+		}
+		a.Events = append(a.Events, p.event)
+	}
+	return a
+}
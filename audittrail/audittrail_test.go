@@ -0,0 +1,129 @@
+package audittrail
+
+import (
+	"errors"
+	"io/ioutil"
+	"testing"
+	"time"
+
+	"github.com/jheth/hellosign-go-sdk/hellosigntest"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParse(t *testing.T) {
+	assert := assert.New(t)
+
+	data, err := ioutil.ReadFile("../fixtures/offer_letter_signed.pdf")
+	assert.Nil(err)
+
+	trail, err := Parse(data)
+	assert.Nil(err)
+	assert.Equal("Offer letter for Jane Doe", trail.Title)
+	assert.Equal("offer_letter.pdf", trail.FileName)
+	assert.Equal("c2a1f4d3e5b6978a0b1c2d3e4f5a6b7c8d9e0f1a", trail.DocumentID)
+	assert.Equal("Signed", trail.Status)
+
+	if assert.Len(trail.Events, 6) {
+		sent := trail.Events[0]
+		assert.Equal(Sent, sent.Action)
+		assert.Equal(time.Date(2014, 9, 18, 17, 53, 12, 0, time.UTC), sent.Time)
+		assert.Equal("Sent for signature to Jane Doe (jane@example.com) and John Smith (john@example.com) from hr@example.com", sent.Description)
+		assert.Equal("203.0.113.10", sent.IP)
+
+		assert.Equal(Event{
+			Action:      Signed,
+			Time:        time.Date(2014, 9, 19, 8, 16, 30, 0, time.UTC),
+			Description: "Signed by John Smith (john@example.com)",
+			Name:        "John Smith",
+			Email:       "john@example.com",
+			IP:          "192.0.2.44",
+		}, trail.Events[4])
+
+		completed := trail.Events[5]
+		assert.Equal(Completed, completed.Action)
+		assert.Equal("The document has been completed.", completed.Description)
+		assert.Empty(completed.IP)
+	}
+
+	signers := trail.Signers()
+	if assert.Len(signers, 2) {
+		assert.Equal("Jane Doe", signers[0].Name)
+		assert.Equal("198.51.100.7", signers[0].IP)
+		assert.Equal(time.Date(2014, 9, 18, 18, 1, 40, 0, time.UTC), *signers[0].ViewedAt)
+		assert.Equal(time.Date(2014, 9, 18, 18, 3, 5, 0, time.UTC), *signers[0].SignedAt)
+		assert.Equal("john@example.com", signers[1].Email)
+	}
+}
+
+func TestParseAuditLinesDayFirst(t *testing.T) {
+	assert := assert.New(t)
+
+	trail := parseAuditLines([]string{
+		"Audit trail",
+		"Audit trail date format\tDD / MM / YYYY",
+		"DECLINED\t03 / 04 / 2021\tDeclined by Jane Doe (jane@example.com)",
+		"09:30 UTC\tIP: 198.51.100.7",
+		"Powered by HelloSign",
+	})
+	assert.Equal([]Event{{
+		Action:      Declined,
+		Time:        time.Date(2021, 4, 3, 9, 30, 0, 0, time.UTC),
+		Description: "Declined by Jane Doe (jane@example.com)",
+		Name:        "Jane Doe",
+		Email:       "jane@example.com",
+		IP:          "198.51.100.7",
+	}}, trail.Events)
+	assert.Nil(trail.Signers()[0].SignedAt)
+}
+
+func TestSplit(t *testing.T) {
+	assert := assert.New(t)
+
+	data, err := ioutil.ReadFile("../fixtures/offer_letter_signed.pdf")
+	assert.Nil(err)
+
+	document, auditTrail, err := Split(data)
+	assert.Nil(err)
+
+	f, err := parsePDF(document)
+	assert.Nil(err)
+	pages, _ := f.pages()
+	assert.Len(pages, 1)
+	lines, _ := f.pageLines(pages[0])
+	assert.Equal("Semper, Inc.", lines[0])
+
+	trail, err := Parse(auditTrail)
+	assert.Nil(err)
+	assert.Len(trail.Events, 6)
+
+	original, _ := ioutil.ReadFile("../fixtures/offer_letter.pdf")
+	_, _, err = Split(original)
+	assert.Equal(ErrNotFound, err)
+}
+
+func TestGet(t *testing.T) {
+	assert := assert.New(t)
+
+	data, _ := ioutil.ReadFile("../fixtures/offer_letter_signed.pdf")
+	mock := &hellosigntest.Mock{
+		GetPDFFunc: func(id string) ([]byte, error) {
+			assert.Equal("abc", id)
+			return data, nil
+		},
+	}
+
+	trail, err := Get(mock, "abc")
+	assert.Nil(err)
+	assert.Equal("Signed", trail.Status)
+
+	pdf, err := GetPDF(mock, "abc")
+	assert.Nil(err)
+	trail, err = Parse(pdf)
+	assert.Nil(err)
+	assert.Len(trail.Signers(), 2)
+
+	failed := errors.New("download failed")
+	mock.GetPDFFunc = func(string) ([]byte, error) { return nil, failed }
+	_, err = Get(mock, "abc")
+	assert.Equal(failed, err)
+}
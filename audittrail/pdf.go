@@ -0,0 +1,1048 @@
+package audittrail
+
+import (
+	"bytes"
+	"compress/zlib"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"math"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode/utf16"
+)
+
+// This file holds the small subset of PDF needed to read the text of
+// HelloSign's merged documents and to copy some of their pages into a new
+// file. It tolerates damaged cross-reference tables by scanning for objects,
+// which also picks up incremental updates and object streams.
+
+type (
+	pdfName    string
+	pdfString  string
+	pdfKeyword string
+	pdfDict    map[pdfName]interface{}
+	pdfRef     struct{ Num, Gen int }
+	pdfStream  struct {
+		Dict pdfDict
+		Data []byte // Still encoded with Dict's Filter.
+	}
+)
+
+// Limits keeping damaged or hostile files from exhausting the stack or memory.
+const (
+	maxPDFDepth  = 64       // Nested arrays and dictionaries.
+	maxPDFStream = 64 << 20 // Decoded bytes per stream.
+)
+
+var (
+	errPDFSyntax    = errors.New("audittrail: invalid PDF")
+	errPDFEncrypted = errors.New("audittrail: encrypted PDFs are not supported")
+	pdfObjectStart  = regexp.MustCompile(`(\d+)[ \t\r\n\f\x00]+(\d+)[ \t\r\n\f\x00]+obj\b`)
+	pdfTrailerStart = regexp.MustCompile(`trailer[ \t\r\n\f\x00]*<<`)
+)
+
+type pdfLexer struct {
+	data  []byte
+	pos   int
+	depth int
+}
+
+func isPDFSpace(c byte) bool {
+	return c == ' ' || c == '\t' || c == '\r' || c == '\n' || c == '\f' || c == 0
+}
+
+func isPDFDelimiter(c byte) bool {
+	return strings.IndexByte("()<>[]{}/%", c) >= 0
+}
+
+func (l *pdfLexer) skipSpace() {
+	for l.pos < len(l.data) {
+		c := l.data[l.pos]
+		switch {
+		case isPDFSpace(c):
+			l.pos++
+		case c == '%':
+			for l.pos < len(l.data) && l.data[l.pos] != '\n' && l.data[l.pos] != '\r' {
+				l.pos++
+			}
+		default:
+			return
+		}
+	}
+}
+
+// regular reads a run of regular characters, such as a keyword or number.
+func (l *pdfLexer) regular() string {
+	start := l.pos
+	for l.pos < len(l.data) && !isPDFSpace(l.data[l.pos]) && !isPDFDelimiter(l.data[l.pos]) {
+		l.pos++
+	}
+	return string(l.data[start:l.pos])
+}
+
+// object reads the next object. Keywords, including content stream operators
+// and closing delimiters, are returned as pdfKeyword.
+func (l *pdfLexer) object() (interface{}, error) {
+	l.skipSpace()
+	if l.pos >= len(l.data) {
+		return nil, errPDFSyntax
+	}
+
+	switch c := l.data[l.pos]; {
+	case c == '/':
+		l.pos++
+		return pdfName(unescapeName(l.regular())), nil
+	case c == '(':
+		return l.literalString()
+	case c == '<' && l.pos+1 < len(l.data) && l.data[l.pos+1] == '<':
+		l.pos += 2
+		return l.dict()
+	case c == '<':
+		return l.hexString()
+	case c == '>' && l.pos+1 < len(l.data) && l.data[l.pos+1] == '>':
+		l.pos += 2
+		return pdfKeyword(">>"), nil
+	case c == '[':
+		l.pos++
+		return l.array()
+	case c == ']' || c == '{' || c == '}' || c == ')' || c == '>':
+		l.pos++
+		return pdfKeyword(c), nil
+	case c == '+' || c == '-' || c == '.' || (c >= '0' && c <= '9'):
+		return l.number()
+	}
+
+	switch word := l.regular(); word {
+	case "true":
+		return true, nil
+	case "false":
+		return false, nil
+	case "null":
+		return nil, nil
+	case "":
+		l.pos++
+		return nil, errPDFSyntax
+	default:
+		return pdfKeyword(word), nil
+	}
+}
+
+func (l *pdfLexer) number() (interface{}, error) {
+	word := l.regular()
+	n, err := strconv.ParseInt(word, 10, 64)
+	if err != nil {
+		f, err := strconv.ParseFloat(word, 64)
+		if err != nil {
+			return nil, errPDFSyntax
+		}
+		return f, nil
+	}
+
+	// An integer may start a reference: num gen R.
+	save := l.pos
+	l.skipSpace()
+	if gen, err := strconv.Atoi(l.regular()); err == nil && n >= 0 {
+		l.skipSpace()
+		if l.regular() == "R" {
+			return pdfRef{Num: int(n), Gen: gen}, nil
+		}
+	}
+	l.pos = save
+	return n, nil
+}
+
+func (l *pdfLexer) literalString() (interface{}, error) {
+	l.pos++
+	var buf []byte
+	depth := 1
+	for l.pos < len(l.data) {
+		c := l.data[l.pos]
+		l.pos++
+		switch c {
+		case '(':
+			depth++
+		case ')':
+			if depth--; depth == 0 {
+				return pdfString(buf), nil
+			}
+		case '\\':
+			if l.pos >= len(l.data) {
+				return nil, errPDFSyntax
+			}
+			c = l.data[l.pos]
+			l.pos++
+			switch c {
+			case 'n':
+				c = '\n'
+			case 'r':
+				c = '\r'
+			case 't':
+				c = '\t'
+			case 'b':
+				c = '\b'
+			case 'f':
+				c = '\f'
+			case '\r':
+				if l.pos < len(l.data) && l.data[l.pos] == '\n' {
+					l.pos++
+				}
+				continue
+			case '\n':
+				continue
+			default:
+				if c >= '0' && c <= '7' {
+					n := int(c - '0')
+					for i := 0; i < 2 && l.pos < len(l.data) && l.data[l.pos] >= '0' && l.data[l.pos] <= '7'; i++ {
+						n = n*8 + int(l.data[l.pos]-'0')
+						l.pos++
+					}
+					c = byte(n)
+				}
+			}
+		}
+		buf = append(buf, c)
+	}
+	return nil, errPDFSyntax
+}
+
+func (l *pdfLexer) hexString() (interface{}, error) {
+	l.pos++
+	end := bytes.IndexByte(l.data[l.pos:], '>')
+	if end < 0 {
+		return nil, errPDFSyntax
+	}
+	digits := make([]byte, 0, end)
+	for _, c := range l.data[l.pos : l.pos+end] {
+		if !isPDFSpace(c) {
+			digits = append(digits, c)
+		}
+	}
+	l.pos += end + 1
+	if len(digits)%2 == 1 {
+		digits = append(digits, '0')
+	}
+
+	buf := make([]byte, len(digits)/2)
+	for i := range buf {
+		n, err := strconv.ParseUint(string(digits[2*i:2*i+2]), 16, 8)
+		if err != nil {
+			return nil, errPDFSyntax
+		}
+		buf[i] = byte(n)
+	}
+	return pdfString(buf), nil
+}
+
+func (l *pdfLexer) dict() (interface{}, error) {
+	if l.depth++; l.depth > maxPDFDepth {
+		return nil, errPDFSyntax
+	}
+	defer func() { l.depth-- }()
+
+	dict := pdfDict{}
+	for {
+		key, err := l.object()
+		if err != nil {
+			return nil, err
+		}
+		if key == pdfKeyword(">>") {
+			return dict, nil
+		}
+		name, ok := key.(pdfName)
+		if !ok {
+			return nil, errPDFSyntax
+		}
+		value, err := l.object()
+		if err != nil {
+			return nil, err
+		}
+		dict[name] = value
+	}
+}
+
+func (l *pdfLexer) array() (interface{}, error) {
+	if l.depth++; l.depth > maxPDFDepth {
+		return nil, errPDFSyntax
+	}
+	defer func() { l.depth-- }()
+
+	array := []interface{}{}
+	for {
+		value, err := l.object()
+		if err != nil {
+			return nil, err
+		}
+		if value == pdfKeyword("]") {
+			return array, nil
+		}
+		array = append(array, value)
+	}
+}
+
+func unescapeName(name string) string {
+	if !strings.Contains(name, "#") {
+		return name
+	}
+	var buf []byte
+	for i := 0; i < len(name); i++ {
+		if name[i] == '#' && i+2 < len(name) {
+			if n, err := strconv.ParseUint(name[i+1:i+3], 16, 8); err == nil {
+				buf = append(buf, byte(n))
+				i += 2
+				continue
+			}
+		}
+		buf = append(buf, name[i])
+	}
+	return string(buf)
+}
+
+// pdfFile is a parsed PDF, indexed by object number.
+type pdfFile struct {
+	objects map[int]interface{}
+	trailer pdfDict
+}
+
+func parsePDF(data []byte) (*pdfFile, error) {
+	if !bytes.HasPrefix(bytes.TrimLeft(data, " \t\r\n"), []byte("%PDF-")) {
+		return nil, errPDFSyntax
+	}
+	f := &pdfFile{objects: map[int]interface{}{}}
+
+	trailerAt := -1
+	for pos := 0; pos < len(data); {
+		loc := pdfObjectStart.FindSubmatchIndex(data[pos:])
+		if loc == nil {
+			break
+		}
+		num, _ := strconv.Atoi(string(data[pos+loc[2] : pos+loc[3]]))
+		l := &pdfLexer{data: data, pos: pos + loc[1]}
+		value, err := f.readIndirect(l)
+		if err != nil {
+			pos += loc[1]
+			continue
+		}
+		f.objects[num] = value
+
+		if stream, ok := value.(*pdfStream); ok {
+			switch stream.Dict["Type"] {
+			case pdfName("ObjStm"):
+				f.readObjectStream(stream)
+			case pdfName("XRef"):
+				f.trailer, trailerAt = stream.Dict, pos+loc[0]
+			}
+		}
+		pos = l.pos
+	}
+
+	for _, loc := range pdfTrailerStart.FindAllIndex(data, -1) {
+		if loc[0] < trailerAt {
+			continue
+		}
+		l := &pdfLexer{data: data, pos: loc[1] - 2}
+		if value, err := l.object(); err == nil {
+			if dict, ok := value.(pdfDict); ok {
+				f.trailer, trailerAt = dict, loc[0]
+			}
+		}
+	}
+
+	if f.trailer == nil {
+		return nil, errPDFSyntax
+	}
+	if _, ok := f.trailer["Encrypt"]; ok {
+		return nil, errPDFEncrypted
+	}
+	return f, nil
+}
+
+// readIndirect reads the body of "num gen obj", including any stream.
+func (f *pdfFile) readIndirect(l *pdfLexer) (interface{}, error) {
+	value, err := l.object()
+	if err != nil {
+		return nil, err
+	}
+	save := l.pos
+	l.skipSpace()
+	dict, ok := value.(pdfDict)
+	if !ok || l.regular() != "stream" {
+		l.pos = save
+		return value, nil
+	}
+
+	if bytes.HasPrefix(l.data[l.pos:], []byte("\r\n")) {
+		l.pos += 2
+	} else if l.pos < len(l.data) && (l.data[l.pos] == '\n' || l.data[l.pos] == '\r') {
+		l.pos++
+	}
+	start := l.pos
+
+	end := -1
+	if length, ok := dict["Length"].(int64); ok && length >= 0 && length <= int64(len(l.data)-start) {
+		rest := bytes.TrimLeft(l.data[start+int(length):], " \t\r\n")
+		if bytes.HasPrefix(rest, []byte("endstream")) {
+			end = start + int(length)
+		}
+	}
+	if end < 0 {
+		i := bytes.Index(l.data[start:], []byte("endstream"))
+		if i < 0 {
+			return nil, errPDFSyntax
+		}
+		end = start + i
+		for end > start && (l.data[end-1] == '\n' || l.data[end-1] == '\r') {
+			end--
+		}
+	}
+	l.pos = end + bytes.Index(l.data[end:], []byte("endstream")) + len("endstream")
+	return &pdfStream{Dict: dict, Data: l.data[start:end]}, nil
+}
+
+func (f *pdfFile) readObjectStream(stream *pdfStream) {
+	data, err := stream.decode()
+	if err != nil {
+		return
+	}
+	n, _ := stream.Dict["N"].(int64)
+	first, _ := stream.Dict["First"].(int64)
+	if first < 0 || first > int64(len(data)) {
+		return
+	}
+
+	header := &pdfLexer{data: data}
+	for i := int64(0); i < n; i++ {
+		num, err1 := header.object()
+		offset, err2 := header.object()
+		if err1 != nil || err2 != nil {
+			return
+		}
+		objNum, ok1 := num.(int64)
+		objOffset, ok2 := offset.(int64)
+		if !ok1 || !ok2 || objOffset < 0 || objOffset >= int64(len(data))-first {
+			return
+		}
+		l := &pdfLexer{data: data, pos: int(first + objOffset)}
+		if value, err := l.object(); err == nil {
+			f.objects[int(objNum)] = value
+		}
+	}
+}
+
+// resolve follows references until it reaches a direct object.
+func (f *pdfFile) resolve(v interface{}) interface{} {
+	for i := 0; i < 32; i++ {
+		ref, ok := v.(pdfRef)
+		if !ok {
+			return v
+		}
+		v = f.objects[ref.Num]
+	}
+	return nil
+}
+
+func (f *pdfFile) dict(v interface{}) pdfDict {
+	switch v := f.resolve(v).(type) {
+	case pdfDict:
+		return v
+	case *pdfStream:
+		return v.Dict
+	}
+	return nil
+}
+
+// decode returns the stream data with its filters removed.
+func (s *pdfStream) decode() ([]byte, error) {
+	var filters []interface{}
+	switch filter := s.Dict["Filter"].(type) {
+	case pdfName:
+		filters = []interface{}{filter}
+	case []interface{}:
+		filters = filter
+	}
+
+	data := s.Data
+	for _, filter := range filters {
+		if filter != pdfName("FlateDecode") {
+			return nil, fmt.Errorf("audittrail: unsupported PDF filter %v", filter)
+		}
+		if params, ok := s.Dict["DecodeParms"].(pdfDict); ok {
+			if predictor, _ := params["Predictor"].(int64); predictor > 1 {
+				return nil, fmt.Errorf("audittrail: unsupported PDF predictor %d", predictor)
+			}
+		}
+		r, err := zlib.NewReader(bytes.NewReader(data))
+		if err != nil {
+			return nil, err
+		}
+		decoded, err := ioutil.ReadAll(io.LimitReader(r, maxPDFStream+1))
+		// Truncated streams are common; keep what could be read.
+		if err != nil && len(decoded) == 0 {
+			return nil, err
+		}
+		if len(decoded) > maxPDFStream {
+			return nil, errPDFSyntax
+		}
+		data = decoded
+	}
+	return data, nil
+}
+
+// pdfPage is a page with the attributes it inherits from the page tree.
+type pdfPage struct {
+	Dict pdfDict
+}
+
+var pdfInherited = []pdfName{"Resources", "MediaBox", "CropBox", "Rotate"}
+
+func (f *pdfFile) pages() ([]pdfPage, error) {
+	catalog := f.dict(f.trailer["Root"])
+	if catalog == nil {
+		return nil, errPDFSyntax
+	}
+
+	var pages []pdfPage
+	visited := map[pdfRef]bool{}
+	var walk func(node interface{}, inherited pdfDict)
+	walk = func(node interface{}, inherited pdfDict) {
+		if ref, ok := node.(pdfRef); ok {
+			if visited[ref] {
+				return
+			}
+			visited[ref] = true
+		}
+		dict := f.dict(node)
+		if dict == nil {
+			return
+		}
+
+		attrs := pdfDict{}
+		for _, key := range pdfInherited {
+			if value, ok := dict[key]; ok {
+				attrs[key] = value
+			} else if value, ok := inherited[key]; ok {
+				attrs[key] = value
+			}
+		}
+
+		if dict["Type"] == pdfName("Pages") || dict["Kids"] != nil && dict["Type"] != pdfName("Page") {
+			kids, _ := f.resolve(dict["Kids"]).([]interface{})
+			for _, kid := range kids {
+				walk(kid, attrs)
+			}
+			return
+		}
+
+		page := pdfDict{}
+		for key, value := range dict {
+			page[key] = value
+		}
+		for key, value := range attrs {
+			page[key] = value
+		}
+		pages = append(pages, pdfPage{Dict: page})
+	}
+	walk(catalog["Pages"], nil)
+
+	if len(pages) == 0 {
+		return nil, errPDFSyntax
+	}
+	return pages, nil
+}
+
+// contents returns the decoded content streams of the page, concatenated.
+func (f *pdfFile) contents(page pdfPage) ([]byte, error) {
+	var streams []interface{}
+	switch contents := f.resolve(page.Dict["Contents"]).(type) {
+	case *pdfStream:
+		streams = []interface{}{contents}
+	case []interface{}:
+		streams = contents
+	}
+
+	var buf bytes.Buffer
+	for _, v := range streams {
+		stream, ok := f.resolve(v).(*pdfStream)
+		if !ok {
+			continue
+		}
+		data, err := stream.decode()
+		if err != nil {
+			return nil, err
+		}
+		buf.Write(data)
+		buf.WriteByte('\n')
+	}
+	return buf.Bytes(), nil
+}
+
+type pdfMatrix [6]float64
+
+var pdfIdentity = pdfMatrix{1, 0, 0, 1, 0, 0}
+
+func (m pdfMatrix) mul(n pdfMatrix) pdfMatrix {
+	return pdfMatrix{
+		m[0]*n[0] + m[1]*n[2],
+		m[0]*n[1] + m[1]*n[3],
+		m[2]*n[0] + m[3]*n[2],
+		m[2]*n[1] + m[3]*n[3],
+		m[4]*n[0] + m[5]*n[2] + n[4],
+		m[4]*n[1] + m[5]*n[3] + n[5],
+	}
+}
+
+// pdfFont decodes the strings shown with a font.
+type pdfFont struct {
+	width   int // Bytes per character code.
+	unicode map[int]string
+}
+
+func (font *pdfFont) text(s pdfString) string {
+	var b strings.Builder
+	for i := 0; i+font.width <= len(s); i += font.width {
+		code := int(s[i])
+		if font.width == 2 {
+			code = code<<8 | int(s[i+1])
+		}
+		if text, ok := font.unicode[code]; ok {
+			b.WriteString(text)
+		} else if font.width == 1 {
+			b.WriteRune(winAnsiRune(byte(code)))
+		}
+	}
+	return b.String()
+}
+
+// winAnsiRune maps the characters of WinAnsiEncoding that differ from Latin-1.
+func winAnsiRune(c byte) rune {
+	switch c {
+	case 0x91:
+		return '‘'
+	case 0x92:
+		return '’'
+	case 0x93:
+		return '“'
+	case 0x94:
+		return '”'
+	case 0x95:
+		return '•'
+	case 0x96:
+		return '–'
+	case 0x97:
+		return '—'
+	}
+	return rune(c)
+}
+
+func (f *pdfFile) font(v interface{}) *pdfFont {
+	font := &pdfFont{width: 1}
+	dict := f.dict(v)
+	if dict == nil {
+		return font
+	}
+	if dict["Subtype"] == pdfName("Type0") {
+		font.width = 2
+	}
+	if stream, ok := f.resolve(dict["ToUnicode"]).(*pdfStream); ok {
+		if data, err := stream.decode(); err == nil {
+			font.unicode = parseCMap(data)
+		}
+	}
+	return font
+}
+
+// parseCMap reads the bfchar and bfrange mappings of a ToUnicode CMap.
+func parseCMap(data []byte) map[int]string {
+	mapping := map[int]string{}
+	l := &pdfLexer{data: data}
+	code := func(v interface{}) (int, bool) {
+		s, ok := v.(pdfString)
+		if !ok || len(s) == 0 || len(s) > 4 {
+			return 0, false
+		}
+		n := 0
+		for i := 0; i < len(s); i++ {
+			n = n<<8 | int(s[i])
+		}
+		return n, true
+	}
+
+	var operands []interface{}
+	for l.pos < len(l.data) {
+		v, err := l.object()
+		if err != nil {
+			break
+		}
+		keyword, ok := v.(pdfKeyword)
+		if !ok {
+			operands = append(operands, v)
+			continue
+		}
+		switch keyword {
+		case "endbfchar":
+			for i := 0; i+1 < len(operands); i += 2 {
+				src, ok := code(operands[i])
+				dst, _ := operands[i+1].(pdfString)
+				if ok {
+					mapping[src] = utf16BE(dst)
+				}
+			}
+		case "endbfrange":
+			for i := 0; i+2 < len(operands); i += 3 {
+				lo, ok1 := code(operands[i])
+				hi, ok2 := code(operands[i+1])
+				if !ok1 || !ok2 || hi < lo || hi-lo > 0xffff {
+					continue
+				}
+				switch dst := operands[i+2].(type) {
+				case pdfString:
+					base := []rune(utf16BE(dst))
+					if len(base) == 0 {
+						continue
+					}
+					for c := lo; c <= hi; c++ {
+						runes := append([]rune{}, base...)
+						runes[len(runes)-1] += rune(c - lo)
+						mapping[c] = string(runes)
+					}
+				case []interface{}:
+					for j, item := range dst {
+						if s, ok := item.(pdfString); ok && lo+j <= hi {
+							mapping[lo+j] = utf16BE(s)
+						}
+					}
+				}
+			}
+		}
+		operands = operands[:0]
+	}
+	return mapping
+}
+
+func utf16BE(s pdfString) string {
+	units := make([]uint16, 0, len(s)/2)
+	for i := 0; i+1 < len(s); i += 2 {
+		units = append(units, uint16(s[i])<<8|uint16(s[i+1]))
+	}
+	return string(utf16.Decode(units))
+}
+
+type pdfTextRun struct {
+	x, y float64
+	text string
+}
+
+// pageLines returns the text of the page as lines from top to bottom. Text
+// placed separately on the same line, such as table columns, is separated by
+// a tab.
+func (f *pdfFile) pageLines(page pdfPage) ([]string, error) {
+	content, err := f.contents(page)
+	if err != nil {
+		return nil, err
+	}
+	fonts := f.dict(f.dict(page.Dict["Resources"])["Font"])
+	loaded := map[pdfName]*pdfFont{}
+
+	var (
+		runs     []pdfTextRun
+		stack    []pdfMatrix
+		ctm      = pdfIdentity
+		tm, tlm  = pdfIdentity, pdfIdentity
+		leading  float64
+		font     = &pdfFont{width: 1}
+		moved    = true
+		operands []interface{}
+	)
+	num := func(i int) float64 {
+		if i >= len(operands) {
+			return 0
+		}
+		return pdfNumber(operands[i])
+	}
+	move := func(tx, ty float64) {
+		tlm = pdfMatrix{1, 0, 0, 1, tx, ty}.mul(tlm)
+		tm, moved = tlm, true
+	}
+	show := func(s pdfString) {
+		text := font.text(s)
+		if moved || len(runs) == 0 {
+			p := tm.mul(ctm)
+			runs = append(runs, pdfTextRun{x: p[4], y: p[5]})
+			moved = false
+		}
+		runs[len(runs)-1].text += text
+	}
+
+	l := &pdfLexer{data: content}
+	for {
+		l.skipSpace()
+		if l.pos >= len(l.data) {
+			break
+		}
+		v, err := l.object()
+		if err != nil {
+			continue
+		}
+		op, ok := v.(pdfKeyword)
+		if !ok {
+			operands = append(operands, v)
+			continue
+		}
+
+		switch op {
+		case "q":
+			stack = append(stack, ctm)
+		case "Q":
+			if len(stack) > 0 {
+				ctm, stack = stack[len(stack)-1], stack[:len(stack)-1]
+			}
+		case "cm":
+			ctm = pdfMatrix{num(0), num(1), num(2), num(3), num(4), num(5)}.mul(ctm)
+		case "BT":
+			tm, tlm, moved = pdfIdentity, pdfIdentity, true
+		case "Tm":
+			tlm = pdfMatrix{num(0), num(1), num(2), num(3), num(4), num(5)}
+			tm, moved = tlm, true
+		case "Td":
+			move(num(0), num(1))
+		case "TD":
+			leading = -num(1)
+			move(num(0), num(1))
+		case "TL":
+			leading = num(0)
+		case "T*":
+			move(0, -leading)
+		case "Tf":
+			if len(operands) != 2 {
+				break
+			}
+			if name, ok := operands[0].(pdfName); ok {
+				if loaded[name] == nil {
+					loaded[name] = f.font(fonts[name])
+				}
+				font = loaded[name]
+			}
+		case "Tj", "'", "\"":
+			if op != "Tj" {
+				move(0, -leading)
+			}
+			if len(operands) > 0 {
+				if s, ok := operands[len(operands)-1].(pdfString); ok {
+					show(s)
+				}
+			}
+		case "TJ":
+			var items []interface{}
+			if len(operands) > 0 {
+				items, _ = operands[len(operands)-1].([]interface{})
+			}
+			for _, item := range items {
+				switch item := item.(type) {
+				case pdfString:
+					show(item)
+				case int64, float64:
+					// A large negative adjustment separates words.
+					if pdfNumber(item) < -250 && len(runs) > 0 {
+						runs[len(runs)-1].text += " "
+					}
+				}
+			}
+		}
+		operands = operands[:0]
+	}
+
+	return groupLines(runs), nil
+}
+
+func pdfNumber(v interface{}) float64 {
+	switch v := v.(type) {
+	case int64:
+		return float64(v)
+	case float64:
+		return v
+	}
+	return 0
+}
+
+// groupLines joins text runs sharing a baseline into tab separated lines.
+func groupLines(runs []pdfTextRun) []string {
+	sort.SliceStable(runs, func(i, j int) bool {
+		if math.Abs(runs[i].y-runs[j].y) > 2 {
+			return runs[i].y > runs[j].y
+		}
+		return runs[i].x < runs[j].x
+	})
+
+	var lines []string
+	var cells []string
+	lineY := math.NaN()
+	flush := func() {
+		if len(cells) > 0 {
+			lines = append(lines, strings.Join(cells, "\t"))
+		}
+		cells = nil
+	}
+	for _, run := range runs {
+		text := strings.TrimSpace(run.text)
+		if text == "" {
+			continue
+		}
+		if math.IsNaN(lineY) || math.Abs(run.y-lineY) > 2 {
+			flush()
+			lineY = run.y
+		}
+		cells = append(cells, text)
+	}
+	flush()
+	return lines
+}
+
+// writePages returns a new PDF made of the given pages. Their annotations
+// are left out, as they may point at pages that are not copied.
+func (f *pdfFile) writePages(pages []pdfPage) []byte {
+	const catalogNum, pagesNum = 1, 2
+	objects := map[int]interface{}{}
+	numbers := map[pdfRef]int{}
+	var queue []pdfRef
+	next := pagesNum + 1
+
+	var rewrite func(v interface{}) interface{}
+	rewrite = func(v interface{}) interface{} {
+		switch v := v.(type) {
+		case pdfRef:
+			n, ok := numbers[v]
+			if !ok {
+				n = next
+				next++
+				numbers[v] = n
+				queue = append(queue, v)
+			}
+			return pdfRef{Num: n}
+		case pdfDict:
+			copied := pdfDict{}
+			for key, value := range v {
+				if key != "Parent" {
+					copied[key] = rewrite(value)
+				}
+			}
+			return copied
+		case []interface{}:
+			copied := make([]interface{}, len(v))
+			for i, value := range v {
+				copied[i] = rewrite(value)
+			}
+			return copied
+		case *pdfStream:
+			return &pdfStream{Dict: rewrite(v.Dict).(pdfDict), Data: v.Data}
+		}
+		return v
+	}
+
+	kids := make([]interface{}, 0, len(pages))
+	for _, page := range pages {
+		dict := pdfDict{}
+		for key, value := range page.Dict {
+			if key != "Annots" && key != "Parent" {
+				dict[key] = value
+			}
+		}
+		n := next
+		next++
+		copied := rewrite(dict).(pdfDict)
+		copied["Parent"] = pdfRef{Num: pagesNum}
+		objects[n] = copied
+		kids = append(kids, pdfRef{Num: n})
+	}
+	for len(queue) > 0 {
+		ref := queue[0]
+		queue = queue[1:]
+		objects[numbers[ref]] = rewrite(f.objects[ref.Num])
+	}
+	objects[catalogNum] = pdfDict{"Type": pdfName("Catalog"), "Pages": pdfRef{Num: pagesNum}}
+	objects[pagesNum] = pdfDict{"Type": pdfName("Pages"), "Kids": kids, "Count": int64(len(kids))}
+
+	var buf bytes.Buffer
+	buf.WriteString("%PDF-1.7\n%\xe2\xe3\xcf\xd3\n")
+	offsets := make([]int, next)
+	for n := 1; n < next; n++ {
+		offsets[n] = buf.Len()
+		fmt.Fprintf(&buf, "%d 0 obj\n", n)
+		if stream, ok := objects[n].(*pdfStream); ok {
+			dict := pdfDict{}
+			for key, value := range stream.Dict {
+				dict[key] = value
+			}
+			dict["Length"] = int64(len(stream.Data))
+			writePDFObject(&buf, dict)
+			buf.WriteString("\nstream\n")
+			buf.Write(stream.Data)
+			buf.WriteString("\nendstream")
+		} else {
+			writePDFObject(&buf, objects[n])
+		}
+		buf.WriteString("\nendobj\n")
+	}
+
+	xref := buf.Len()
+	fmt.Fprintf(&buf, "xref\n0 %d\n0000000000 65535 f \n", next)
+	for n := 1; n < next; n++ {
+		fmt.Fprintf(&buf, "%010d 00000 n \n", offsets[n])
+	}
+	fmt.Fprintf(&buf, "trailer\n<< /Size %d /Root %d 0 R >>\nstartxref\n%d\n%%%%EOF\n", next, catalogNum, xref)
+	return buf.Bytes()
+}
+
+func writePDFObject(buf *bytes.Buffer, v interface{}) {
+	switch v := v.(type) {
+	case nil:
+		buf.WriteString("null")
+	case bool:
+		buf.WriteString(strconv.FormatBool(v))
+	case int64:
+		buf.WriteString(strconv.FormatInt(v, 10))
+	case float64:
+		buf.WriteString(strconv.FormatFloat(v, 'f', -1, 64))
+	case pdfName:
+		buf.WriteByte('/')
+		for i := 0; i < len(v); i++ {
+			c := v[i]
+			if c <= ' ' || c > '~' || c == '#' || isPDFDelimiter(c) {
+				fmt.Fprintf(buf, "#%02x", c)
+				continue
+			}
+			buf.WriteByte(c)
+		}
+	case pdfString:
+		fmt.Fprintf(buf, "<%x>", string(v))
+	case pdfRef:
+		fmt.Fprintf(buf, "%d %d R", v.Num, v.Gen)
+	case pdfKeyword:
+		buf.WriteString(string(v))
+	case []interface{}:
+		buf.WriteByte('[')
+		for i, item := range v {
+			if i > 0 {
+				buf.WriteByte(' ')
+			}
+			writePDFObject(buf, item)
+		}
+		buf.WriteByte(']')
+	case pdfDict:
+		keys := make([]string, 0, len(v))
+		for key := range v {
+			keys = append(keys, string(key))
+		}
+		sort.Strings(keys)
+		buf.WriteString("<<")
+		for _, key := range keys {
+			buf.WriteByte(' ')
+			writePDFObject(buf, pdfName(key))
+			buf.WriteByte(' ')
+			writePDFObject(buf, v[pdfName(key)])
+		}
+		buf.WriteString(" >>")
+	}
+}
@@ -0,0 +1,26 @@
+//go:build go1.18
+// +build go1.18
+
+package audittrail
+
+import (
+	"io/ioutil"
+	"testing"
+)
+
+func FuzzParse(f *testing.F) {
+	data, err := ioutil.ReadFile("../fixtures/offer_letter_signed.pdf")
+	if err != nil {
+		f.Fatal(err)
+	}
+	f.Add(data)
+	for _, seed := range malformedPDFs {
+		f.Add([]byte(seed))
+	}
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		// Only panics fail; errors are expected.
+		Parse(data)
+		Split(data)
+	})
+}
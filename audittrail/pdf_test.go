@@ -0,0 +1,237 @@
+package audittrail
+
+import (
+	"bytes"
+	"compress/zlib"
+	"fmt"
+	"io/ioutil"
+	"regexp"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPDFPageLines(t *testing.T) {
+	assert := assert.New(t)
+
+	data, err := ioutil.ReadFile("../fixtures/offer_letter.pdf")
+	assert.Nil(err)
+	f, err := parsePDF(data)
+	assert.Nil(err)
+	pages, err := f.pages()
+	assert.Nil(err)
+	assert.Len(pages, 1)
+
+	lines, err := f.pageLines(pages[0])
+	assert.Nil(err)
+	assert.Equal("Semper, Inc.", lines[0])
+	assert.Contains(lines, "Semper, Inc. is pleased to extend to you an offer of full-time employment.")
+	assert.Contains(lines, "employment relationship with you at any time, with or without cause.")
+}
+
+func TestPDFObjectStream(t *testing.T) {
+	assert := assert.New(t)
+
+	var packed bytes.Buffer
+	w := zlib.NewWriter(&packed)
+	w.Write([]byte("1 0 2 34 << /Type /Catalog /Pages 2 0 R >> << /Type /Pages /Kids [] /Count 0 >>"))
+	w.Close()
+	data := fmt.Sprintf("%%PDF-1.5\n3 0 obj\n<< /Type /ObjStm /N 2 /First 9 /Filter /FlateDecode /Length %d >>\nstream\n%s\nendstream\nendobj\n"+
+		"trailer\n<< /Root 1 0 R /Size 4 >>\n%%%%EOF\n", packed.Len(), packed.String())
+
+	f, err := parsePDF([]byte(data))
+	assert.Nil(err)
+	assert.Equal(pdfRef{Num: 2}, f.dict(f.trailer["Root"])["Pages"])
+	assert.Equal(int64(0), f.dict(pdfRef{Num: 2})["Count"])
+
+	_, err = parsePDF([]byte("%PDF-1.4\ntrailer\n<< /Root 1 0 R /Encrypt 5 0 R >>\n"))
+	assert.Equal(errPDFEncrypted, err)
+	_, err = parsePDF([]byte("not a pdf"))
+	assert.Equal(errPDFSyntax, err)
+}
+
+func TestPDFWritePages(t *testing.T) {
+	assert := assert.New(t)
+
+	data, err := ioutil.ReadFile("../fixtures/offer_letter_signed.pdf")
+	assert.Nil(err)
+	f, err := parsePDF(data)
+	assert.Nil(err)
+	pages, err := f.pages()
+	assert.Nil(err)
+	assert.Len(pages, 3)
+
+	out := f.writePages(pages[1:])
+
+	// Every cross-reference entry points at its object.
+	xref := bytes.LastIndex(out, []byte("xref\n"))
+	entries := regexp.MustCompile(`(\d{10}) 00000 n`).FindAllSubmatch(out[xref:], -1)
+	for i, entry := range entries {
+		offset, _ := strconv.Atoi(string(entry[1]))
+		assert.True(bytes.HasPrefix(out[offset:], []byte(fmt.Sprintf("%d 0 obj\n", i+1))), "object %d", i+1)
+	}
+
+	copied, err := parsePDF(out)
+	assert.Nil(err)
+	copiedPages, err := copied.pages()
+	assert.Nil(err)
+	assert.Len(copiedPages, 2)
+	for i, page := range copiedPages {
+		want, _ := f.pageLines(pages[i+1])
+		got, err := copied.pageLines(page)
+		assert.Nil(err)
+		assert.Equal(want, got)
+	}
+}
+
+// malformedPDFs once crashed the parser; they also seed FuzzParse.
+var malformedPDFs = []string{
+	"%PDF-1.5\n1 0 obj\n<< /Type /ObjStm /N 1 /First -50 >>\nstream\n1 0 <<>>\nendstream\nendobj\ntrailer\n<< /Root 1 0 R >>\n",
+	"%PDF-1.5\n1 0 obj\n<< /Type /ObjStm /N 1 /First 0 >>\nstream\n1 -40 <<>>\nendstream\nendobj\ntrailer\n<< /Root 1 0 R >>\n",
+	"%PDF-1.5\n1 0 obj\n<< /Type /ObjStm /N 1 /First 9223372036854775807 >>\nstream\n1 1 <<>>\nendstream\nendobj\ntrailer\n<< /Root 1 0 R >>\n",
+	"%PDF-1.5\n1 0 obj\n<< /Length -20 >>\nstream\nabc\nendstream\nendobj\ntrailer\n<< /Root 1 0 R >>\n",
+	"%PDF-1.5\n1 0 obj\n<< /Length 9223372036854775807 >>\nstream\nabc\nendstream\nendobj\ntrailer\n<< /Root 1 0 R >>\n",
+	"%PDF-1.5\n1 0 obj\n" + strings.Repeat("[", 100000) + "\nendobj\ntrailer\n<< /Root 1 0 R >>\n",
+}
+
+func TestPDFMalformed(t *testing.T) {
+	assert := assert.New(t)
+
+	for i, data := range malformedPDFs {
+		assert.NotPanics(func() {
+			_, err := Parse([]byte(data))
+			assert.Equal(errPDFSyntax, err, "case %d", i)
+		}, "case %d", i)
+	}
+
+	// The stream data is found by its endstream keyword instead.
+	f, err := parsePDF([]byte(malformedPDFs[3]))
+	if assert.Nil(err) {
+		assert.Equal([]byte("abc"), f.objects[1].(*pdfStream).Data)
+	}
+}
+
+func TestPDFLexer(t *testing.T) {
+	assert := assert.New(t)
+
+	l := &pdfLexer{data: []byte(`% comment
+/Name#20With#2fSlash (a\(b\)\n\101\
+c (nested)) <48 65 6c6c 6F> <<>> [1 -2.5 +3 .5 true false null 12 0 R 7 0 obj] ) Tj`)}
+	var objects []interface{}
+	for {
+		l.skipSpace()
+		if l.pos >= len(l.data) {
+			break
+		}
+		v, err := l.object()
+		assert.Nil(err)
+		objects = append(objects, v)
+	}
+	assert.Equal([]interface{}{
+		pdfName("Name With/Slash"),
+		pdfString("a(b)\nAc (nested)"),
+		pdfString("Hello"),
+		pdfDict{},
+		[]interface{}{int64(1), -2.5, int64(3), .5, true, false, nil, pdfRef{Num: 12}, int64(7), int64(0), pdfKeyword("obj")},
+		pdfKeyword(")"),
+		pdfKeyword("Tj"),
+	}, objects)
+
+	for _, bad := range []string{"(unterminated", "<4142", "<<  /A  >>", "<< 1 2 >>", "[1 2", "<zz>"} {
+		_, err := (&pdfLexer{data: []byte(bad)}).object()
+		assert.Equal(errPDFSyntax, err, bad)
+	}
+}
+
+func TestPDFDecode(t *testing.T) {
+	assert := assert.New(t)
+
+	var packed bytes.Buffer
+	w := zlib.NewWriter(&packed)
+	w.Write([]byte("BT /F1 12 Tf (Hi) Tj ET"))
+	w.Close()
+
+	stream := &pdfStream{Dict: pdfDict{"Filter": []interface{}{pdfName("FlateDecode")}}, Data: packed.Bytes()}
+	data, err := stream.decode()
+	assert.Nil(err)
+	assert.Equal("BT /F1 12 Tf (Hi) Tj ET", string(data))
+
+	// A truncated stream keeps what could be read.
+	stream.Data = packed.Bytes()[:packed.Len()-6]
+	data, err = stream.decode()
+	assert.Nil(err)
+	assert.Equal("BT /F1 12 Tf (Hi) Tj ET", string(data))
+
+	stream.Dict["DecodeParms"] = pdfDict{"Predictor": int64(12)}
+	_, err = stream.decode()
+	assert.EqualError(err, "audittrail: unsupported PDF predictor 12")
+
+	_, err = (&pdfStream{Dict: pdfDict{"Filter": pdfName("DCTDecode")}}).decode()
+	assert.EqualError(err, "audittrail: unsupported PDF filter DCTDecode")
+
+	var bomb bytes.Buffer
+	w = zlib.NewWriter(&bomb)
+	w.Write(make([]byte, maxPDFStream+1))
+	w.Close()
+	_, err = (&pdfStream{Dict: pdfDict{"Filter": pdfName("FlateDecode")}, Data: bomb.Bytes()}).decode()
+	assert.Equal(errPDFSyntax, err)
+}
+
+func TestPDFCMap(t *testing.T) {
+	assert := assert.New(t)
+
+	mapping := parseCMap([]byte(`/CIDInit /ProcSet findresource begin
+2 beginbfchar
+<0003> <0020>
+<0011> <D83DDE00>
+endbfchar
+2 beginbfrange
+<0024> <0026> <0041>
+<0030> <0031> [<0078> <0079>]
+<0040> <0020> <0041>
+endbfrange
+end`))
+	assert.Equal(map[int]string{
+		0x03: " ",
+		0x11: "😀",
+		0x24: "A", 0x25: "B", 0x26: "C",
+		0x30: "x", 0x31: "y",
+	}, mapping)
+
+	font := &pdfFont{width: 2, unicode: mapping}
+	assert.Equal("AB C", font.text(pdfString("\x00\x24\x00\x25\x00\x03\x00\x26\x00")))
+	font = &pdfFont{width: 1}
+	assert.Equal("“quoted” – é", font.text(pdfString("\x93quoted\x94 \x96 \xe9")))
+}
+
+func TestPDFPageText(t *testing.T) {
+	assert := assert.New(t)
+
+	content := `q 1 0 0 1 0 700 cm
+BT /F1 10 Tf 72 0 Td (Name) Tj 200 0 Td (Jane Doe) Tj ET
+BT 14 TL 72 -20 Td (first) Tj T* [(sec) -30 (ond) -400 (line)] TJ (third) ' ET
+Q
+BT 1 0 0 1 72 100 Tm (footer) Tj ET`
+	data := fmt.Sprintf("%%PDF-1.4\n1 0 obj\n<< /Type /Catalog /Pages 2 0 R >>\nendobj\n"+
+		"2 0 obj\n<< /Type /Pages /Kids [3 0 R] /Count 1 /Resources << /Font << /F1 4 0 R >> >> >>\nendobj\n"+
+		"3 0 obj\n<< /Type /Page /Parent 2 0 R /Contents 5 0 R >>\nendobj\n"+
+		"4 0 obj\n<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica >>\nendobj\n"+
+		"5 0 obj\n<< /Length %d >>\nstream\n%s\nendstream\nendobj\n"+
+		"trailer\n<< /Root 1 0 R >>\n", len(content), content)
+
+	f, err := parsePDF([]byte(data))
+	if !assert.Nil(err) {
+		return
+	}
+	pages, err := f.pages()
+	if !assert.Nil(err) {
+		return
+	}
+	assert.NotNil(pages[0].Dict["Resources"], "Resources are inherited from the page tree")
+
+	lines, err := f.pageLines(pages[0])
+	assert.Nil(err)
+	assert.Equal([]string{"Name\tJane Doe", "first", "second line", "third", "footer"}, lines)
+}
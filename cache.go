@@ -0,0 +1,170 @@
+package hellosign
+
+import (
+	"io/ioutil"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// FileCache stores documents downloaded by GetFiles, keyed by signature
+// request ID and file type. The client only stores the files of completed
+// requests, which never change.
+type FileCache interface {
+	// Get returns the cached files, or false when there are none.
+	Get(signatureRequestID, fileType string) ([]byte, bool)
+	// Put stores the files. Failing to store them is not an error for the caller.
+	Put(signatureRequestID, fileType string, data []byte)
+}
+
+// WithFileCache - Serves GetFiles and SaveFile from cache once a request is complete.
+func (m *Client) WithFileCache(cache FileCache) *Client {
+	m.FileCache = cache
+
+	return m
+}
+
+// DiskCache is a FileCache keeping files in a directory, evicting the least
+// recently used ones once they take more than MaxBytes. Use is tracked with
+// the files' modification times, so it survives restarts.
+type DiskCache struct {
+	dir      string
+	maxBytes int64
+
+	mu      sync.Mutex
+	entries map[string]*diskEntry
+	size    int64
+}
+
+type diskEntry struct {
+	size int64
+	used time.Time
+}
+
+// NewDiskCache returns a DiskCache in dir, creating it if needed and picking
+// up files left by a previous process.
+func NewDiskCache(dir string, maxBytes int64) (*DiskCache, error) {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, err
+	}
+	infos, err := ioutil.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	c := &DiskCache{dir: dir, maxBytes: maxBytes, entries: map[string]*diskEntry{}}
+	for _, info := range infos {
+		if info.IsDir() || strings.HasPrefix(info.Name(), ".") {
+			continue
+		}
+		c.entries[info.Name()] = &diskEntry{size: info.Size(), used: info.ModTime()}
+		c.size += info.Size()
+	}
+	c.evict()
+	return c, nil
+}
+
+// Get returns the cached files and marks them as recently used.
+func (c *DiskCache) Get(signatureRequestID, fileType string) ([]byte, bool) {
+	name := diskCacheName(signatureRequestID, fileType)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entry, ok := c.entries[name]
+	if !ok {
+		return nil, false
+	}
+	data, err := ioutil.ReadFile(filepath.Join(c.dir, name))
+	if err != nil {
+		c.remove(name)
+		return nil, false
+	}
+
+	entry.used = time.Now()
+	os.Chtimes(filepath.Join(c.dir, name), entry.used, entry.used)
+	return data, true
+}
+
+// Put stores the files, evicting others as needed. Files larger than the
+// whole cache are not stored.
+func (c *DiskCache) Put(signatureRequestID, fileType string, data []byte) {
+	size := int64(len(data))
+	if size > c.maxBytes {
+		return
+	}
+	name := diskCacheName(signatureRequestID, fileType)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	// Write to a hidden temporary file first so readers never see a partial file.
+	tmp, err := ioutil.TempFile(c.dir, ".tmp-")
+	if err != nil {
+		return
+	}
+	_, err = tmp.Write(data)
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = os.Rename(tmp.Name(), filepath.Join(c.dir, name))
+	}
+	if err != nil {
+		os.Remove(tmp.Name())
+		return
+	}
+
+	if entry, ok := c.entries[name]; ok {
+		c.size -= entry.size
+	}
+	c.entries[name] = &diskEntry{size: size, used: time.Now()}
+	c.size += size
+	c.evict()
+}
+
+// Size returns the bytes currently cached.
+func (c *DiskCache) Size() int64 {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.size
+}
+
+// evict removes the least recently used files until the cache fits.
+func (c *DiskCache) evict() {
+	if c.size <= c.maxBytes {
+		return
+	}
+
+	names := make([]string, 0, len(c.entries))
+	for name := range c.entries {
+		names = append(names, name)
+	}
+	sort.Slice(names, func(i, j int) bool {
+		return c.entries[names[i]].used.Before(c.entries[names[j]].used)
+	})
+	for _, name := range names {
+		if c.size <= c.maxBytes {
+			break
+		}
+		os.Remove(filepath.Join(c.dir, name))
+		c.remove(name)
+	}
+}
+
+func (c *DiskCache) remove(name string) {
+	if entry, ok := c.entries[name]; ok {
+		c.size -= entry.size
+		delete(c.entries, name)
+	}
+}
+
+// diskCacheName escapes the ID so it is always a single file name.
+func diskCacheName(signatureRequestID, fileType string) string {
+	return url.PathEscape(signatureRequestID) + "." + url.PathEscape(fileType)
+}
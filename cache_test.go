@@ -0,0 +1,131 @@
+package hellosign
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDiskCacheEvictsLeastRecentlyUsed(t *testing.T) {
+	assert := assert.New(t)
+
+	dir := t.TempDir()
+	cache, err := NewDiskCache(dir, 10)
+	assert.Nil(err)
+
+	cache.Put("a", "pdf", []byte("aaaa"))
+	cache.Put("b", "pdf", []byte("bbbb"))
+	_, ok := cache.Get("a", "pdf")
+	assert.True(ok)
+
+	// b is the least recently used, so it makes room for c.
+	cache.Put("c", "zip", []byte("cccc"))
+	_, ok = cache.Get("b", "pdf")
+	assert.False(ok)
+	data, ok := cache.Get("a", "pdf")
+	assert.True(ok)
+	assert.Equal("aaaa", string(data))
+	assert.Equal(int64(8), cache.Size())
+
+	cache.Put("d", "pdf", []byte("too large to cache"))
+	_, ok = cache.Get("d", "pdf")
+	assert.False(ok)
+
+	cache.Put("../e", "pdf", []byte("e"))
+	_, err = os.Stat(filepath.Join(dir, "..%2Fe.pdf"))
+	assert.Nil(err)
+
+	// A new cache picks up the files and their order.
+	old := time.Now().Add(-time.Hour)
+	assert.Nil(os.Chtimes(filepath.Join(dir, "a.pdf"), old, old))
+	reopened, err := NewDiskCache(dir, 5)
+	assert.Nil(err)
+	_, ok = reopened.Get("a", "pdf")
+	assert.False(ok)
+	_, ok = reopened.Get("c", "zip")
+	assert.True(ok)
+}
+
+func TestGetFilesCachesCompletedRequests(t *testing.T) {
+	assert := assert.New(t)
+
+	downloads := 0
+	complete := false
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasPrefix(r.URL.Path, "/v3/signature_request/files/") {
+			downloads++
+			fmt.Fprintf(w, "%%PDF-1.4 download %d", downloads)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprintf(w, `{"signature_request": {"signature_request_id": "abc", "is_complete": %t}}`, complete)
+	}))
+	defer server.Close()
+
+	cache, err := NewDiskCache(t.TempDir(), 1<<20)
+	assert.Nil(err)
+	client := Client{APIKey: "secret-key", BaseURL: server.URL + "/v3/"}
+	client.WithFileCache(cache)
+
+	data, err := client.GetPDF("abc")
+	assert.Nil(err)
+	assert.Equal("%PDF-1.4 download 1", string(data))
+	_, ok := cache.Get("abc", "pdf")
+	assert.False(ok, "incomplete requests are not cached")
+
+	complete = true
+	data, err = client.GetPDF("abc")
+	assert.Nil(err)
+	assert.Equal("%PDF-1.4 download 2", string(data))
+
+	path := filepath.Join(t.TempDir(), "abc.pdf")
+	info, err := client.SaveFile("abc", "pdf", path)
+	assert.Nil(err)
+	assert.Equal(int64(len("%PDF-1.4 download 2")), info.Size())
+	assert.Equal(2, downloads)
+
+	_, err = client.GetFiles("abc", "zip")
+	assert.Nil(err)
+	assert.Equal(3, downloads)
+}
+
+func TestGetFilesChecksStatusOnlyWithCache(t *testing.T) {
+	assert := assert.New(t)
+
+	var paths []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		paths = append(paths, r.URL.Path)
+		if strings.HasPrefix(r.URL.Path, "/v3/signature_request/files/") {
+			fmt.Fprint(w, "%PDF-1.4")
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, `{"signature_request": {"signature_request_id": "abc", "is_complete": true}}`)
+	}))
+	defer server.Close()
+
+	client := Client{APIKey: "secret-key", BaseURL: server.URL + "/v3/"}
+	_, err := client.GetPDF("abc")
+	assert.Nil(err)
+	assert.Equal([]string{"/v3/signature_request/files/abc"}, paths)
+
+	cache, err := NewDiskCache(t.TempDir(), 1<<20)
+	assert.Nil(err)
+	client.WithFileCache(cache)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err = client.files(ctx, "abc", "pdf")
+	if assert.NotNil(err) {
+		assert.Contains(err.Error(), "context canceled")
+	}
+	assert.Len(paths, 1, "the status check uses the caller's context")
+}
@@ -0,0 +1,352 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"net/mail"
+
+	hellosign "github.com/jheth/hellosign-go-sdk"
+)
+
+var sendCommand = &command{
+	name:    "send",
+	summary: "Create and send a signature request from flags or a YAML/JSON spec.",
+	setup: func(flags *flag.FlagSet) func(c *cli, args []string) error {
+		var files, fileURLs, signers, cc, metadata, vars stringsFlag
+		spec := flags.String("spec", "", "YAML or JSON spec `file`; other flags override it")
+		flags.Var(&vars, "var", "spec template variable as `key=value` (repeatable)")
+		flags.Var(&files, "file", "document `path` to upload (repeatable)")
+		flags.Var(&fileURLs, "file-url", "document `URL` to fetch (repeatable)")
+		flags.Var(&signers, "signer", "signer as \"Name <email>\" (repeatable)")
+		flags.Var(&cc, "cc", "CC email `address` (repeatable)")
+		flags.Var(&metadata, "metadata", "metadata as `key=value` (repeatable)")
+		title := flags.String("title", "", "request title")
+		subject := flags.String("subject", "", "email subject")
+		message := flags.String("message", "", "email message")
+		clientID := flags.String("client-id", "", "API app client `ID`, required with -embedded")
+		test := flags.Bool("test", false, "send in test mode")
+		embedded := flags.Bool("embedded", false, "create an embedded signature request")
+		ordered := flags.Bool("ordered", false, "have signers sign one after another, in the order of -signer")
+		idempotencyKey := flags.String("idempotency-key", "", "`key` making repeated sends create the request only once")
+
+		return func(c *cli, args []string) error {
+			if len(args) != 0 {
+				return errUsage
+			}
+
+			request := hellosign.CreationRequest{}
+			if *spec != "" {
+				data, err := keyValues("var", vars)
+				if err != nil {
+					return err
+				}
+				if request, err = loadSpec(*spec, data); err != nil {
+					return err
+				}
+			}
+
+			set := map[string]bool{}
+			flags.Visit(func(f *flag.Flag) { set[f.Name] = true })
+
+			if set["file"] {
+				request.File = files
+			}
+			if set["file-url"] {
+				request.FileURL = fileURLs
+			}
+			if set["signer"] {
+				request.Signers = nil
+				for i, value := range signers {
+					address, err := mail.ParseAddress(value)
+					if err != nil {
+						return fmt.Errorf("signer %q: %v", value, err)
+					}
+					signer := hellosign.Signer{Name: address.Name, Email: address.Address}
+					if *ordered {
+						signer.Order = i
+					}
+					request.Signers = append(request.Signers, signer)
+				}
+			}
+			if set["cc"] {
+				request.CCEmailAddresses = cc
+			}
+			if set["metadata"] {
+				values, err := keyValues("metadata", metadata)
+				if err != nil {
+					return err
+				}
+				if request.Metadata == nil {
+					request.Metadata = map[string]string{}
+				}
+				for key, value := range values {
+					request.Metadata[key] = value
+				}
+			}
+			if set["title"] {
+				request.Title = *title
+			}
+			if set["subject"] {
+				request.Subject = *subject
+			}
+			if set["message"] {
+				request.Message = *message
+			}
+			if set["client-id"] {
+				request.ClientID = *clientID
+			}
+			if set["test"] {
+				request.TestMode = *test
+			}
+			request.IdempotencyKey = *idempotencyKey
+
+			client, err := c.client()
+			if err != nil {
+				return err
+			}
+
+			var res *hellosign.SignatureRequest
+			if *embedded {
+				res, err = client.CreateEmbeddedSignatureRequest(request)
+			} else {
+				res, err = client.CreateSignatureRequest(request)
+			}
+			if err != nil {
+				return err
+			}
+			return c.printSignatureRequest(res)
+		}
+	},
+}
+
+var getCommand = &command{
+	name:    "get",
+	args:    "<signature_request_id>",
+	summary: "Show a signature request and the status of each signer.",
+	setup: func(flags *flag.FlagSet) func(c *cli, args []string) error {
+		return func(c *cli, args []string) error {
+			if len(args) != 1 {
+				return errUsage
+			}
+			client, err := c.client()
+			if err != nil {
+				return err
+			}
+
+			res, err := client.GetSignatureRequest(args[0])
+			if err != nil {
+				return err
+			}
+			return c.printSignatureRequest(res)
+		}
+	},
+}
+
+var listCommand = &command{
+	name:    "list",
+	summary: "List signature requests.",
+	setup: func(flags *flag.FlagSet) func(c *cli, args []string) error {
+		opts := hellosign.ListOptions{}
+		flags.StringVar(&opts.Query, "query", "", "search `terms`, e.g. title:Offer or an email address")
+		flags.StringVar(&opts.AccountID, "account", "", "account `id`, or \"all\" for the whole team")
+		flags.IntVar(&opts.Page, "page", 0, "page `number`")
+		flags.IntVar(&opts.PageSize, "page-size", 0, "results per page, up to 100")
+		status := flags.String("status", "", "only show requests in this overall `status`, e.g. awaiting_signature or complete")
+
+		return func(c *cli, args []string) error {
+			if len(args) != 0 {
+				return errUsage
+			}
+			client, err := c.client()
+			if err != nil {
+				return err
+			}
+
+			res, err := client.ListSignatureRequestsWithOptions(opts)
+			if err != nil {
+				return err
+			}
+			if *status != "" {
+				requests := []*hellosign.SignatureRequest{}
+				for _, request := range res.SignatureRequests {
+					if string(request.OverallStatus()) == *status {
+						requests = append(requests, request)
+					}
+				}
+				res.SignatureRequests = requests
+			}
+			return c.printList(res)
+		}
+	},
+}
+
+var downloadCommand = &command{
+	name:    "download",
+	args:    "<signature_request_id>",
+	summary: "Save the documents of a signature request as a merged pdf or a zip.",
+	setup: func(flags *flag.FlagSet) func(c *cli, args []string) error {
+		fileType := flags.String("type", "pdf", "file `type`: pdf or zip")
+		out := flags.String("out", "", "destination `path`; defaults to <id>.<type>")
+
+		return func(c *cli, args []string) error {
+			if len(args) != 1 {
+				return errUsage
+			}
+			if *fileType != "pdf" && *fileType != "zip" {
+				return fmt.Errorf("unknown file type %q", *fileType)
+			}
+			path := *out
+			if path == "" {
+				path = args[0] + "." + *fileType
+			}
+
+			client, err := c.client()
+			if err != nil {
+				return err
+			}
+
+			info, err := client.SaveFile(args[0], *fileType, path)
+			if err != nil {
+				return err
+			}
+			return c.print(map[string]interface{}{"path": path, "size": info.Size()}, func(t *table) {
+				t.row("PATH", "SIZE")
+				t.row(path, info.Size())
+			})
+		}
+	},
+}
+
+var remindCommand = &command{
+	name:    "remind",
+	args:    "<signature_request_id>",
+	summary: "Send a reminder email to a signer who has not signed yet.",
+	setup: func(flags *flag.FlagSet) func(c *cli, args []string) error {
+		email := flags.String("email", "", "signer email `address` (required)")
+
+		return func(c *cli, args []string) error {
+			if len(args) != 1 || *email == "" {
+				return errUsage
+			}
+			client, err := c.client()
+			if err != nil {
+				return err
+			}
+
+			res, err := client.RemindSignatureRequest(args[0], *email)
+			if err != nil {
+				return err
+			}
+			return c.printSignatureRequest(res)
+		}
+	},
+}
+
+var cancelCommand = &command{
+	name:    "cancel",
+	args:    "<signature_request_id>",
+	summary: "Cancel an incomplete signature request. This is not reversible.",
+	setup: func(flags *flag.FlagSet) func(c *cli, args []string) error {
+		return func(c *cli, args []string) error {
+			if len(args) != 1 {
+				return errUsage
+			}
+			client, err := c.client()
+			if err != nil {
+				return err
+			}
+
+			response, err := client.CancelSignatureRequest(args[0])
+			if err != nil {
+				return err
+			}
+			response.Body.Close()
+			if response.StatusCode >= 300 {
+				return fmt.Errorf("cancel failed with status %d", response.StatusCode)
+			}
+			return c.print(map[string]interface{}{"signature_request_id": args[0], "canceled": true}, func(t *table) {
+				t.row("SIGNATURE REQUEST", "CANCELED")
+				t.row(args[0], true)
+			})
+		}
+	},
+}
+
+var signURLCommand = &command{
+	name:    "sign-url",
+	args:    "<signature_id>",
+	summary: "Print the embedded signing URL for a signer's signature_id.",
+	setup: func(flags *flag.FlagSet) func(c *cli, args []string) error {
+		return func(c *cli, args []string) error {
+			if len(args) != 1 {
+				return errUsage
+			}
+			client, err := c.client()
+			if err != nil {
+				return err
+			}
+
+			res, err := client.GetEmbeddedSignURL(args[0])
+			if err != nil {
+				return err
+			}
+			return c.print(res, func(t *table) {
+				t.row("SIGN URL", "EXPIRES AT")
+				t.row(res.SignURL, formatTime(&res.ExpiresAt))
+			})
+		}
+	},
+}
+
+var eventsCommand = &command{
+	name:    "events",
+	args:    "verify [-file path]",
+	summary: "Verify the event hash of a callback body read from a file or stdin.",
+	setup: func(flags *flag.FlagSet) func(c *cli, args []string) error {
+		file := flags.String("file", "", "callback body `path`; defaults to stdin")
+
+		return func(c *cli, args []string) error {
+			if len(args) == 0 || args[0] != "verify" {
+				return errUsage
+			}
+			// Accept flags after the subcommand too: events verify -file body.txt
+			if err := flags.Parse(args[1:]); err != nil || flags.NArg() != 0 {
+				return errUsage
+			}
+			apiKey, err := c.apiKey()
+			if err != nil {
+				return err
+			}
+
+			var data []byte
+			if *file != "" {
+				data, err = ioutil.ReadFile(*file)
+			} else {
+				data, err = ioutil.ReadAll(c.stdin)
+			}
+			if err != nil {
+				return err
+			}
+
+			event, err := hellosign.ParseEvent(data)
+			if err != nil {
+				return err
+			}
+
+			valid := event.Verify(apiKey)
+			requestID := ""
+			if event.SignatureRequest != nil {
+				requestID = event.SignatureRequest.SignatureRequestID
+			}
+			err = c.print(map[string]interface{}{"event": event.Event, "signature_request_id": requestID, "valid": valid}, func(t *table) {
+				t.row("EVENT", "TIME", "SIGNATURE REQUEST", "VALID")
+				t.row(event.Event.EventType, event.Event.EventTime, requestID, valid)
+			})
+			if err == nil && !valid {
+				err = fmt.Errorf("event hash was not signed with %s", apiKeyEnv)
+			}
+			return err
+		}
+	},
+}
@@ -0,0 +1,226 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	hellosign "github.com/jheth/hellosign-go-sdk"
+	"github.com/jheth/hellosign-go-sdk/hellosigntest"
+	"github.com/stretchr/testify/assert"
+)
+
+func runCLI(server *hellosigntest.Server, stdin string, args ...string) (int, string, string) {
+	var stdout, stderr bytes.Buffer
+	env := map[string]string{
+		apiKeyEnv:  hellosigntest.APIKey,
+		baseURLEnv: server.URL + "/v3",
+	}
+	c := &cli{
+		stdin:  strings.NewReader(stdin),
+		stdout: &stdout,
+		stderr: &stderr,
+		getenv: func(key string) string { return env[key] },
+	}
+	code := c.run(args)
+	return code, stdout.String(), stderr.String()
+}
+
+func TestSendGetRemindCancel(t *testing.T) {
+	assert := assert.New(t)
+
+	server := hellosigntest.NewServer()
+	defer server.Close()
+
+	spec := filepath.Join(t.TempDir(), "offer.yaml")
+	ioutil.WriteFile(spec, []byte(`
+title: Offer letter
+test_mode: true
+files: [../../fixtures/offer_letter.pdf]
+signers:
+  - name: Jane Doe
+    email: jane@example.com
+metadata:
+  contract_id: "{{ .contract }}"
+`), 0644)
+
+	code, out, errOut := runCLI(server, "", "send", "-o", "json", "-spec", spec, "-var", "contract=42",
+		"-signer", "Jane Doe <jane@example.com>", "-signer", "John Doe <john@example.com>", "-metadata", "team=hr")
+	if !assert.Equal(0, code, errOut) {
+		return
+	}
+
+	res := &hellosign.SignatureRequest{}
+	assert.Nil(json.Unmarshal([]byte(out), res))
+	assert.Equal("Offer letter", res.Title)
+	assert.Equal(map[string]interface{}{"contract_id": "42", "team": "hr"}, res.Metadata)
+	assert.Equal(2, len(res.Signatures))
+	assert.Equal("john@example.com", res.Signatures[1].SignerEmailAddress)
+	assert.Equal(0, res.Signatures[1].Order, "signers sign in any order without -ordered")
+
+	code, out, _ = runCLI(server, "", "get", res.SignatureRequestID)
+	assert.Equal(0, code)
+	assert.Contains(out, "awaiting_signature")
+	assert.Contains(out, "jane@example.com")
+
+	code, _, errOut = runCLI(server, "", "remind", "-email", "john@example.com", res.SignatureRequestID)
+	assert.Equal(0, code, errOut)
+
+	code, out, _ = runCLI(server, "", "cancel", res.SignatureRequestID)
+	assert.Equal(0, code)
+	assert.Contains(out, res.SignatureRequestID)
+
+	code, _, errOut = runCLI(server, "", "get", res.SignatureRequestID)
+	assert.Equal(1, code)
+	assert.Equal("hellosign get: deleted: This resource has been deleted\n", errOut)
+}
+
+func TestSendOrdered(t *testing.T) {
+	assert := assert.New(t)
+
+	server := hellosigntest.NewServer()
+	defer server.Close()
+
+	code, out, errOut := runCLI(server, "", "send", "-o", "json", "-test", "-ordered", "-file", "../../fixtures/offer_letter.pdf",
+		"-signer", "Jane Doe <jane@example.com>", "-signer", "John Doe <john@example.com>")
+	if !assert.Equal(0, code, errOut) {
+		return
+	}
+
+	res := &hellosign.SignatureRequest{}
+	assert.Nil(json.Unmarshal([]byte(out), res))
+	assert.Equal(0, res.Signatures[0].Order)
+	assert.Equal(1, res.Signatures[1].Order)
+}
+
+func TestSendIdempotencyKey(t *testing.T) {
+	assert := assert.New(t)
+
+	server := hellosigntest.NewServer()
+	defer server.Close()
+
+	ids := []string{}
+	for i := 0; i < 2; i++ {
+		code, out, errOut := runCLI(server, "", "send", "-o", "json", "-test", "-idempotency-key", "offer-42",
+			"-file", "../../fixtures/offer_letter.pdf", "-signer", "Jane Doe <jane@example.com>")
+		if !assert.Equal(0, code, errOut) {
+			return
+		}
+		res := &hellosign.SignatureRequest{}
+		assert.Nil(json.Unmarshal([]byte(out), res))
+		ids = append(ids, res.SignatureRequestID)
+	}
+
+	assert.Equal(ids[0], ids[1])
+	assert.Equal(1, len(server.SignatureRequests()))
+}
+
+func TestListDownloadAndSignURL(t *testing.T) {
+	assert := assert.New(t)
+
+	server := hellosigntest.NewServer()
+	defer server.Close()
+
+	client := server.Client()
+	for _, title := range []string{"Offer", "NDA"} {
+		_, err := client.CreateEmbeddedSignatureRequest(hellosign.CreationRequest{
+			TestMode: true,
+			ClientID: "client",
+			File:     []string{"../../fixtures/offer_letter.pdf"},
+			Title:    title,
+			Signers:  []hellosign.Signer{{Name: "Jane Doe", Email: "jane@example.com"}},
+		})
+		assert.Nil(err, "Should not return error")
+	}
+	nda := server.SignatureRequests()[0]
+	assert.Nil(server.Sign(nda.SignatureRequestID, nda.Signatures[0].SignatureID, nil))
+
+	code, out, _ := runCLI(server, "", "list", "-query", "title:offer", "-o", "json")
+	assert.Equal(0, code)
+	list := &hellosign.ListResponse{}
+	assert.Nil(json.Unmarshal([]byte(out), list))
+	if assert.Equal(1, len(list.SignatureRequests)) {
+		assert.Equal("Offer", list.SignatureRequests[0].Title)
+	}
+
+	code, out, _ = runCLI(server, "", "list", "-status", "complete")
+	assert.Equal(0, code)
+	assert.Contains(out, "NDA")
+	assert.NotContains(out, "Offer")
+	assert.Contains(out, "1/1")
+
+	path := filepath.Join(t.TempDir(), "nda.pdf")
+	code, _, errOut := runCLI(server, "", "download", "-out", path, nda.SignatureRequestID)
+	assert.Equal(0, code, errOut)
+	data, _ := ioutil.ReadFile(path)
+	assert.True(bytes.HasPrefix(data, []byte("%PDF")))
+
+	code, out, _ = runCLI(server, "", "sign-url", nda.Signatures[0].SignatureID)
+	assert.Equal(0, code)
+	assert.Contains(out, "embeddedSign?signature_id="+nda.Signatures[0].SignatureID)
+}
+
+func TestEventsVerify(t *testing.T) {
+	assert := assert.New(t)
+
+	server := hellosigntest.NewServer()
+	defer server.Close()
+
+	event := hellosign.Event{Event: hellosign.EventInfo{
+		EventTime: "1348177752",
+		EventType: hellosign.EventCallbackTest,
+		EventHash: hellosign.EventHash(hellosigntest.APIKey, "1348177752", hellosign.EventCallbackTest),
+	}}
+	data, _ := json.Marshal(event)
+	body := url.Values{"json": {string(data)}}.Encode()
+
+	code, out, _ := runCLI(server, body, "events", "verify")
+	assert.Equal(0, code)
+	assert.Contains(out, "callback_test")
+	assert.Contains(out, "true")
+
+	file := filepath.Join(t.TempDir(), "event.json")
+	event.Event.EventHash = "forged"
+	data, _ = json.Marshal(event)
+	ioutil.WriteFile(file, data, 0644)
+
+	code, _, errOut := runCLI(server, "", "events", "verify", "-file", file)
+	assert.Equal(1, code)
+	assert.Contains(errOut, "event hash was not signed with HELLOSIGN_API_KEY")
+}
+
+func TestUsage(t *testing.T) {
+	assert := assert.New(t)
+
+	server := hellosigntest.NewServer()
+	defer server.Close()
+
+	code, _, errOut := runCLI(server, "")
+	assert.Equal(2, code)
+	assert.Contains(errOut, "sign-url")
+
+	code, _, errOut = runCLI(server, "", "remind", "abc")
+	assert.Equal(2, code)
+	assert.Contains(errOut, "usage: hellosign remind")
+
+	code, _, errOut = runCLI(server, "", "get", "-o", "xml", "abc")
+	assert.Equal(2, code)
+	assert.Contains(errOut, `unknown output format "xml"`)
+
+	code, _, _ = runCLI(server, "", "bogus")
+	assert.Equal(2, code)
+}
+
+func TestMissingAPIKey(t *testing.T) {
+	var stderr bytes.Buffer
+	c := &cli{stdout: ioutil.Discard, stderr: &stderr, getenv: os.Getenv}
+	os.Unsetenv(apiKeyEnv)
+
+	assert.Equal(t, 1, c.run([]string{"get", "abc"}))
+	assert.Equal(t, "hellosign get: HELLOSIGN_API_KEY is not set\n", stderr.String())
+}
@@ -0,0 +1,71 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"text/tabwriter"
+
+	hellosign "github.com/jheth/hellosign-go-sdk"
+)
+
+// table writes tab-aligned rows.
+type table struct {
+	w *tabwriter.Writer
+}
+
+func (t *table) row(values ...interface{}) {
+	cells := make([]string, len(values))
+	for i, value := range values {
+		cells[i] = fmt.Sprint(value)
+	}
+	fmt.Fprintln(t.w, strings.Join(cells, "\t"))
+}
+
+// print writes v as indented JSON with -o json, or the rows added by
+// writeTable otherwise.
+func (c *cli) print(v interface{}, writeTable func(t *table)) error {
+	if c.output == "json" {
+		encoder := json.NewEncoder(c.stdout)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(v)
+	}
+
+	t := &table{w: tabwriter.NewWriter(c.stdout, 0, 4, 2, ' ', 0)}
+	writeTable(t)
+	return t.w.Flush()
+}
+
+func (c *cli) printSignatureRequest(res *hellosign.SignatureRequest) error {
+	return c.print(res, func(t *table) {
+		t.row("SIGNATURE REQUEST", "TITLE", "STATUS", "TEST", "CREATED AT")
+		t.row(res.SignatureRequestID, res.Title, res.OverallStatus(), res.TestMode, formatTime(&res.CreatedAt))
+		t.row()
+		t.row("SIGNATURE", "ORDER", "NAME", "EMAIL", "STATUS", "SIGNED AT")
+		for _, sig := range res.Signatures {
+			t.row(sig.SignatureID, sig.Order, sig.SignerName, sig.SignerEmailAddress, sig.StatusCode, formatTime(sig.SignedAt))
+		}
+	})
+}
+
+func (c *cli) printList(res *hellosign.ListResponse) error {
+	return c.print(res, func(t *table) {
+		t.row("SIGNATURE REQUEST", "TITLE", "STATUS", "SIGNERS", "CREATED AT")
+		for _, request := range res.SignatureRequests {
+			signed := 0
+			for _, sig := range request.Signatures {
+				if sig.StatusCode.IsSigned() {
+					signed++
+				}
+			}
+			t.row(request.SignatureRequestID, request.Title, request.OverallStatus(), fmt.Sprintf("%d/%d", signed, len(request.Signatures)), formatTime(&request.CreatedAt))
+		}
+	})
+}
+
+func formatTime(ts *hellosign.Timestamp) string {
+	if ts == nil || ts.IsZero() {
+		return "-"
+	}
+	return ts.UTC().Format("2006-01-02 15:04:05")
+}
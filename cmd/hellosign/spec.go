@@ -0,0 +1,37 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	hellosign "github.com/jheth/hellosign-go-sdk"
+)
+
+// loadSpec reads the spec at path, resolving {{ .key }} from vars.
+func loadSpec(path string, vars map[string]string) (hellosign.CreationRequest, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return hellosign.CreationRequest{}, err
+	}
+	defer f.Close()
+
+	request, err := hellosign.LoadSpec(f, vars)
+	if err != nil {
+		return request, fmt.Errorf("%s: %v", path, err)
+	}
+	return request, nil
+}
+
+// keyValues parses repeated key=value flags.
+func keyValues(name string, values []string) (map[string]string, error) {
+	result := map[string]string{}
+	for _, value := range values {
+		i := strings.Index(value, "=")
+		if i < 1 {
+			return nil, fmt.Errorf("%s %q: want key=value", name, value)
+		}
+		result[value[:i]] = value[i+1:]
+	}
+	return result, nil
+}
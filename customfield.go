@@ -0,0 +1,94 @@
+package hellosign
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// Custom field types.
+const (
+	CustomFieldTypeText     = "text"
+	CustomFieldTypeCheckbox = "checkbox"
+)
+
+// NewTextCustomField returns a text custom field pre-filled with value.
+func NewTextCustomField(name, value string) CustomField {
+	return CustomField{Name: name, Type: CustomFieldTypeText, Value: value}
+}
+
+// NewCheckboxCustomField returns a checkbox custom field.
+func NewCheckboxCustomField(name string, checked bool) CustomField {
+	return CustomField{Name: name, Type: CustomFieldTypeCheckbox, Value: checked}
+}
+
+// WithEditor returns a copy of the field that the given signer role may edit.
+func (c CustomField) WithEditor(editor string, required bool) CustomField {
+	c.Editor = &editor
+	c.Required = required
+	return c
+}
+
+// StringValue returns the value of a text field.
+func (c CustomField) StringValue() (string, bool) {
+	value, ok := c.Value.(string)
+	return value, ok
+}
+
+// BoolValue returns the value of a checkbox field.
+func (c CustomField) BoolValue() (bool, bool) {
+	value, ok := c.Value.(bool)
+	return value, ok
+}
+
+func (c CustomField) validate() error {
+	if c.Name == "" && c.ApiID == "" {
+		return fmt.Errorf("name or api_id is required")
+	}
+
+	switch c.Type {
+	case "", CustomFieldTypeText:
+		if _, ok := c.Value.(string); !ok && c.Value != nil {
+			return fmt.Errorf("text value must be a string, got %T", c.Value)
+		}
+	case CustomFieldTypeCheckbox:
+		if _, ok := c.Value.(bool); !ok && c.Value != nil {
+			return fmt.Errorf("checkbox value must be a bool, got %T", c.Value)
+		}
+	default:
+		return fmt.Errorf("unknown type %q", c.Type)
+	}
+
+	if c.Required && (c.Editor == nil || *c.Editor == "") {
+		return fmt.Errorf("required custom fields need an editor")
+	}
+	return nil
+}
+
+// TypedCustomFields - Returns SignatureRequest.CustomFields decoded into CustomField values.
+func (s *SignatureRequest) TypedCustomFields() ([]CustomField, error) {
+	data, err := json.Marshal(s.CustomFields)
+	if err != nil {
+		return nil, err
+	}
+
+	fields := []CustomField{}
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return nil, err
+	}
+	return fields, nil
+}
+
+// CustomFieldByName - Returns the custom field whose name or api_id matches, or nil.
+func (s *SignatureRequest) CustomFieldByName(name string) (*CustomField, error) {
+	fields, err := s.TypedCustomFields()
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range fields {
+		if fields[i].Name == name || fields[i].ApiID == name {
+			return &fields[i], nil
+		}
+	}
+	return nil, nil
+}
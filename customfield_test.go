@@ -0,0 +1,77 @@
+package hellosign
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCustomFieldsEncoded(t *testing.T) {
+	assert := assert.New(t)
+
+	request := creationRequest()
+	request.CustomFields = []CustomField{
+		NewTextCustomField("Cost", "$20,000").WithEditor("Client", true),
+		NewCheckboxCustomField("Rush", true),
+	}
+
+	object, form := encodeRequest(t, request)
+
+	assert.Equal(`[{"name":"Cost","type":"text","value":"$20,000","required":true,"editor":"Client"},{"name":"Rush","type":"checkbox","value":true,"required":false}]`, form.Value["custom_fields"][0])
+	assert.Equal(`[{"editor":"Client","name":"Cost","required":true,"type":"text","value":"$20,000"},{"name":"Rush","required":false,"type":"checkbox","value":true}]`, jsonValue(object["custom_fields"]))
+}
+
+func TestCustomFieldsValidate(t *testing.T) {
+	assert := assert.New(t)
+
+	request := creationRequest()
+	request.CustomFields = []CustomField{
+		{Name: "Cost", Type: CustomFieldTypeText, Value: 20000},
+		{Name: "Rush", Type: CustomFieldTypeCheckbox, Required: true},
+	}
+
+	err := request.Validate()
+	assert.Equal("invalid request: custom_fields[0]: text value must be a string, got int; custom_fields[1]: required custom fields need an editor", err.Error())
+}
+
+func TestSignatureRequestTypedCustomFields(t *testing.T) {
+	assert := assert.New(t)
+
+	vcr := fixture("fixtures/list_signature_requests")
+	defer vcr.Stop() // Make sure recorder is stopped once done with it
+
+	client := createVcrClient(vcr)
+
+	res, err := client.ListSignatureRequests()
+	assert.Nil(err, "Should not return error")
+
+	var fields []CustomField
+	var req *SignatureRequest
+	for _, sr := range res.SignatureRequests {
+		if len(sr.CustomFields) == 4 {
+			req = sr
+			fields, err = sr.TypedCustomFields()
+			break
+		}
+	}
+
+	assert.Nil(err, "Should not return error")
+	if !assert.Equal(4, len(fields)) {
+		return
+	}
+
+	assert.Equal(CustomFieldTypeText, fields[0].Type)
+	assert.True(fields[0].Required)
+	assert.Nil(fields[0].Editor)
+
+	checked, ok := fields[2].BoolValue()
+	assert.True(ok)
+	assert.False(checked)
+
+	field, err := req.CustomFieldByName(fields[3].ApiID)
+	assert.Nil(err, "Should not return error")
+	assert.Equal(CustomFieldTypeCheckbox, field.Type)
+
+	field, _ = req.CustomFieldByName("missing")
+	assert.Nil(field)
+}
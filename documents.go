@@ -0,0 +1,128 @@
+package hellosign
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/url"
+	"path"
+	"strings"
+)
+
+// Document is one document of a signature request, as returned by GetDocuments.
+type Document struct {
+	// Name is the file name of the document. When GetDocuments is given the
+	// uploads it is the original name of the upload, e.g. offer.docx.
+	// Otherwise it is the name in HelloSign's archive, which converts
+	// documents to PDF and gives them a .pdf extension.
+	Name string
+	// Upload is the path or URL the document was matched to, if any.
+	Upload string
+	// Size is the uncompressed size in bytes.
+	Size int64
+
+	open func() (io.ReadCloser, error)
+}
+
+// NewDocument returns a Document holding data, e.g. for stubbing GetDocuments.
+func NewDocument(name string, data []byte) Document {
+	return Document{
+		Name: name,
+		Size: int64(len(data)),
+		open: func() (io.ReadCloser, error) {
+			return ioutil.NopCloser(bytes.NewReader(data)), nil
+		},
+	}
+}
+
+// Open returns a reader for the document's contents. It can be called more
+// than once, also concurrently.
+func (d Document) Open() (io.ReadCloser, error) {
+	if d.open == nil {
+		return nil, errors.New("hellosign: document has no contents")
+	}
+	return d.open()
+}
+
+// GetDocuments - Downloads the documents of a signature request as a zip and
+// returns one Document per file. Pass the request's File (or FileURL) as
+// uploads to get the documents in that order, named after the uploads; they
+// are matched to the archive by name. Without uploads, the documents are
+// returned in archive order; HelloSign doesn't document that order, so don't
+// rely on it matching the uploads.
+func (m *Client) GetDocuments(ctx context.Context, signatureRequestID string, uploads ...string) ([]Document, error) {
+	data, err := m.files(ctx, signatureRequestID, "zip")
+	if err != nil {
+		return nil, err
+	}
+	documents, err := readDocuments(data)
+	if err != nil || len(uploads) == 0 {
+		return documents, err
+	}
+	return matchUploads(documents, uploads)
+}
+
+// readDocuments lists the files of a zip in archive order.
+func readDocuments(data []byte) ([]Document, error) {
+	archive, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		return nil, err
+	}
+
+	documents := make([]Document, 0, len(archive.File))
+	for _, file := range archive.File {
+		if file.FileInfo().IsDir() {
+			continue
+		}
+		// Keep only the base name, so callers can safely save documents by Name.
+		name := path.Base(strings.Replace(file.Name, "\\", "/", -1))
+		documents = append(documents, Document{
+			Name: name,
+			Size: int64(file.UncompressedSize64),
+			open: file.Open,
+		})
+	}
+	return documents, nil
+}
+
+// matchUploads orders documents as uploads. Each upload takes the first
+// unmatched document named like it with a .pdf extension, so repeated names
+// keep their archive order.
+func matchUploads(documents []Document, uploads []string) ([]Document, error) {
+	if len(documents) != len(uploads) {
+		return nil, fmt.Errorf("hellosign: archive holds %d documents for %d uploads", len(documents), len(uploads))
+	}
+
+	matched := make([]Document, 0, len(uploads))
+	used := make([]bool, len(documents))
+	for _, upload := range uploads {
+		name := uploadName(upload)
+		want := strings.TrimSuffix(name, path.Ext(name)) + ".pdf"
+
+		found := false
+		for i, document := range documents {
+			if !used[i] && strings.EqualFold(document.Name, want) {
+				used[i], found = true, true
+				document.Name, document.Upload = name, upload
+				matched = append(matched, document)
+				break
+			}
+		}
+		if !found {
+			return nil, fmt.Errorf("hellosign: no document in the archive for %s", upload)
+		}
+	}
+	return matched, nil
+}
+
+// uploadName returns the file name of a File path or FileURL.
+func uploadName(upload string) string {
+	if u, err := url.Parse(upload); err == nil && u.Scheme != "" && u.Host != "" {
+		upload = u.Path
+	}
+	return path.Base(strings.Replace(upload, "\\", "/", -1))
+}
@@ -0,0 +1,117 @@
+package hellosign
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetDocuments(t *testing.T) {
+	assert := assert.New(t)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal("/v3/signature_request/files/abc", r.URL.Path)
+		assert.Equal("zip", r.URL.Query().Get("file_type"))
+		body, _ := ioutil.ReadAll(r.Body)
+		assert.Empty(body, "GET parameters go in the query string")
+
+		w.Header().Set("Content-Type", "application/zip")
+		archive := zip.NewWriter(w)
+		archive.Create("contracts/")
+		for _, name := range []string{"offer_letter.pdf", "contracts/nda.pdf", "offer_letter.pdf"} {
+			entry, _ := archive.Create(name)
+			entry.Write([]byte("%PDF-1.4 " + name))
+		}
+		archive.Close()
+	}))
+	defer server.Close()
+
+	client := Client{APIKey: "secret-key", BaseURL: server.URL + "/v3/"}
+	documents, err := client.GetDocuments(context.Background(), "abc")
+	if !assert.Nil(err) {
+		return
+	}
+
+	names := []string{}
+	for _, document := range documents {
+		names = append(names, document.Name)
+	}
+	assert.Equal([]string{"offer_letter.pdf", "nda.pdf", "offer_letter.pdf"}, names)
+	assert.Equal(int64(len("%PDF-1.4 contracts/nda.pdf")), documents[1].Size)
+
+	reader, err := documents[1].Open()
+	assert.Nil(err)
+	data, err := ioutil.ReadAll(reader)
+	reader.Close()
+	assert.Nil(err)
+	assert.Equal("%PDF-1.4 contracts/nda.pdf", string(data))
+}
+
+func TestGetDocumentsContext(t *testing.T) {
+	assert := assert.New(t)
+
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+	}))
+	defer server.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	client := Client{APIKey: "secret-key", BaseURL: server.URL + "/v3/"}
+	_, err := client.GetDocuments(ctx, "abc")
+	if assert.NotNil(err) {
+		assert.Contains(err.Error(), "context canceled")
+	}
+	assert.Equal(0, requests)
+}
+
+func TestMatchUploads(t *testing.T) {
+	assert := assert.New(t)
+
+	var b bytes.Buffer
+	archive := zip.NewWriter(&b)
+	for _, name := range []string{"nda.pdf", "offer_letter.pdf", "Offer_Letter.pdf"} {
+		entry, _ := archive.Create(name)
+		entry.Write([]byte(name))
+	}
+	archive.Close()
+
+	documents, err := readDocuments(b.Bytes())
+	if !assert.Nil(err) {
+		return
+	}
+
+	matched, err := matchUploads(documents, []string{
+		"contracts/offer_letter.docx",
+		"https://example.com/files/nda.pdf?token=secret",
+		`C:\contracts\Offer_Letter.pdf`,
+	})
+	if !assert.Nil(err) {
+		return
+	}
+	var names, uploads, contents []string
+	for _, document := range matched {
+		names = append(names, document.Name)
+		uploads = append(uploads, document.Upload)
+		reader, _ := document.Open()
+		data, _ := ioutil.ReadAll(reader)
+		reader.Close()
+		contents = append(contents, string(data))
+	}
+	assert.Equal([]string{"offer_letter.docx", "nda.pdf", "Offer_Letter.pdf"}, names)
+	assert.Equal([]string{"contracts/offer_letter.docx", "https://example.com/files/nda.pdf?token=secret", `C:\contracts\Offer_Letter.pdf`}, uploads)
+	assert.Equal([]string{"offer_letter.pdf", "nda.pdf", "Offer_Letter.pdf"}, contents)
+
+	_, err = matchUploads(documents, []string{"a.pdf"})
+	assert.EqualError(err, "hellosign: archive holds 3 documents for 1 uploads")
+	_, err = matchUploads(documents, []string{"nda.pdf", "nda.pdf", "offer_letter.pdf"})
+	assert.EqualError(err, "hellosign: no document in the archive for nda.pdf")
+}
@@ -0,0 +1,296 @@
+package hellosign
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DryRun answers API calls locally instead of sending them to HelloSign.
+// Requests are still encoded and pass through the whole middleware chain; the
+// final step records them and synthesizes a plausible response: created
+// requests get fake IDs and awaiting_signature signers, and later calls for
+// those IDs see them. Sign and Decline stand in for the signers.
+//
+//	client := hellosign.Client{APIKey: "unused", DryRun: hellosign.NewDryRun("/tmp/hellosign")}
+//
+// A DryRun is also an http.Handler serving the same API under /v3/, which is
+// how hellosigntest.Server fakes HelloSign over HTTP.
+type DryRun struct {
+	// Dir, when set, receives each request as a raw HTTP/1.1 file named
+	// 0001-POST-signature_request-send.http, readable with http.ReadRequest.
+	// The Authorization header is left out.
+	Dir string
+	// Now returns the current time for timestamps. Defaults to time.Now.
+	Now func() time.Time
+	// OnEvent, when set, is called with each callback event HelloSign would
+	// send, such as EventSignatureRequestSent after a create. signatureID is
+	// the signature the event concerns, if any. Sign and Decline return its
+	// error; API calls ignore it, as HelloSign doesn't report failed callbacks.
+	OnEvent func(eventType string, request *SignatureRequest, signatureID string) error
+
+	mu       sync.Mutex
+	requests []DryRunRequest
+	records  map[string]*dryRunRecord
+	order    []string
+}
+
+// DryRunRequest is a request recorded by DryRun.
+type DryRunRequest struct {
+	Method string
+	Path   string // Path relative to the API base, with query, e.g. "signature_request/list?page=2".
+	Header http.Header
+	Body   []byte
+}
+
+// dryRunRecord is a signature request created in a dry run.
+type dryRunRecord struct {
+	request   *SignatureRequest
+	documents []string // File names, as uploaded or from file_url.
+	canceled  bool
+}
+
+// dryRunSite holds the base URLs responses link to: HelloSign's own for a
+// Client, the server's under ServeHTTP.
+type dryRunSite struct {
+	api string // e.g. https://api.hellosign.com/v3/
+	app string // e.g. https://app.hellosign.com/
+}
+
+// NewDryRun returns a DryRun writing requests to dir, or only keeping them in
+// memory when dir is empty.
+func NewDryRun(dir string) *DryRun {
+	return &DryRun{Dir: dir}
+}
+
+// Requests returns the requests recorded from a Client, oldest first.
+// Requests served by ServeHTTP are not recorded.
+func (d *DryRun) Requests() []DryRunRequest {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	return append([]DryRunRequest{}, d.requests...)
+}
+
+// SignatureRequests returns copies of every signature request created in the
+// dry run, canceled ones included, newest first.
+func (d *DryRun) SignatureRequests() []*SignatureRequest {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	requests := []*SignatureRequest{}
+	for i := len(d.order) - 1; i >= 0; i-- {
+		requests = append(requests, copySignatureRequest(d.records[d.order[i]].request))
+	}
+	return requests
+}
+
+// SignatureRequest returns a copy of a signature request created in the dry run.
+func (d *DryRun) SignatureRequest(id string) (*SignatureRequest, bool) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	record, ok := d.records[id]
+	if !ok {
+		return nil, false
+	}
+	return copySignatureRequest(record.request), true
+}
+
+// Sign marks the signature as signed and records the given form field
+// responses keyed by api_id. The request completes once every signer has signed.
+func (d *DryRun) Sign(signatureRequestID, signatureID string, responses map[string]interface{}) error {
+	d.mu.Lock()
+	record, sig, err := d.lookupSignature(signatureRequestID, signatureID)
+	if err != nil {
+		d.mu.Unlock()
+		return err
+	}
+
+	now := Timestamp{Time: d.now()}
+	sig.StatusCode = StatusSigned
+	sig.SignedAt = &now
+
+	ids := make([]string, 0, len(responses))
+	for id := range responses {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	for _, id := range ids {
+		record.request.ResponseData = append(record.request.ResponseData, &ResponseData{
+			ApiID:       id,
+			SignatureID: signatureID,
+			Value:       NewFieldValue(responses[id]),
+		})
+	}
+
+	events := []string{EventSignatureRequestSigned}
+	if record.request.OverallStatus() == RequestStatusComplete {
+		record.request.IsComplete = true
+		events = append(events, EventSignatureRequestAllSigned)
+	}
+	request := copySignatureRequest(record.request)
+	d.mu.Unlock()
+
+	for _, eventType := range events {
+		if err := d.emit(eventType, request, signatureID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Decline marks the signature, and therefore the request, as declined.
+func (d *DryRun) Decline(signatureRequestID, signatureID, reason string) error {
+	d.mu.Lock()
+	record, sig, err := d.lookupSignature(signatureRequestID, signatureID)
+	if err != nil {
+		d.mu.Unlock()
+		return err
+	}
+
+	sig.StatusCode = StatusDeclined
+	sig.DeclineReason = reason
+	record.request.IsDeclined = true
+	request := copySignatureRequest(record.request)
+	d.mu.Unlock()
+
+	return d.emit(EventSignatureRequestDeclined, request, signatureID)
+}
+
+func (d *DryRun) lookupSignature(signatureRequestID, signatureID string) (*dryRunRecord, *Signature, error) {
+	record, ok := d.records[signatureRequestID]
+	if !ok || record.canceled {
+		return nil, nil, fmt.Errorf("hellosign: unknown signature request %s", signatureRequestID)
+	}
+	for _, sig := range record.request.Signatures {
+		if sig.SignatureID == signatureID {
+			if !sig.StatusCode.IsPending() {
+				return nil, nil, fmt.Errorf("hellosign: signature %s is %s", signatureID, sig.StatusCode)
+			}
+			return record, sig, nil
+		}
+	}
+	return nil, nil, fmt.Errorf("hellosign: unknown signature %s", signatureID)
+}
+
+func (d *DryRun) emit(eventType string, request *SignatureRequest, signatureID string) error {
+	if d.OnEvent == nil {
+		return nil
+	}
+	return d.OnEvent(eventType, request, signatureID)
+}
+
+func (d *DryRun) now() time.Time {
+	if d.Now != nil {
+		return d.Now()
+	}
+	return time.Now()
+}
+
+// roundTrip is the last step of the chain when Client.DryRun is set.
+func (d *DryRun) roundTrip(request *http.Request, path string) (*http.Response, error) {
+	var body []byte
+	if request.Body != nil {
+		var err error
+		if body, err = ioutil.ReadAll(request.Body); err != nil {
+			return nil, err
+		}
+		request.Body.Close()
+	}
+
+	header := request.Header.Clone()
+	header.Del("Authorization")
+
+	d.mu.Lock()
+	d.requests = append(d.requests, DryRunRequest{Method: request.Method, Path: path, Header: header, Body: body})
+	n := len(d.requests)
+	d.mu.Unlock()
+
+	if d.Dir != "" {
+		if err := d.write(n, path, request, header, body); err != nil {
+			return nil, err
+		}
+	}
+
+	site := dryRunSite{
+		api: strings.TrimSuffix(request.URL.String(), path),
+		app: "https://app.hellosign.com/",
+	}
+	route := strings.SplitN(path, "?", 2)[0]
+	status, contentType, data := d.serve(request.Method, route, request.URL.Query(), header.Get("Content-Type"), body, site)
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", status, http.StatusText(status)),
+		StatusCode:    status,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        http.Header{"Content-Type": {contentType}, requestIDHeader: {"dry-run-" + strconv.Itoa(n)}},
+		Body:          ioutil.NopCloser(bytes.NewReader(data)),
+		ContentLength: int64(len(data)),
+		Request:       request,
+	}, nil
+}
+
+// ServeHTTP answers HelloSign API requests under /v3/ from the dry run's
+// signature requests. It doesn't check credentials.
+func (d *DryRun) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	body, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	scheme := "http"
+	if r.TLS != nil {
+		scheme = "https"
+	}
+	base := scheme + "://" + r.Host + "/"
+	site := dryRunSite{api: base + "v3/", app: base}
+
+	route := strings.TrimPrefix(path.Clean(r.URL.Path), "/v3/")
+	status, contentType, data := d.serve(r.Method, route, r.URL.Query(), r.Header.Get("Content-Type"), body, site)
+	w.Header().Set("Content-Type", contentType)
+	w.WriteHeader(status)
+	w.Write(data)
+}
+
+// serve decodes the request parameters and answers the request.
+func (d *DryRun) serve(method, route string, query url.Values, contentType string, body []byte, site dryRunSite) (int, string, []byte) {
+	params, err := parseDryRunParams(method, query, contentType, body)
+	if err != nil {
+		return dryRunError(http.StatusBadRequest, "bad_request", err.Error())
+	}
+	return d.respond(method, route, params, site)
+}
+
+func (d *DryRun) write(n int, path string, request *http.Request, header http.Header, body []byte) error {
+	if err := os.MkdirAll(d.Dir, 0755); err != nil {
+		return err
+	}
+
+	route := strings.SplitN(path, "?", 2)[0]
+	route = strings.Trim(strings.Replace(route, "/", "-", -1), "-")
+	name := fmt.Sprintf("%04d-%s-%s.http", n, request.Method, route)
+
+	out := request.Clone(request.Context())
+	out.Header = header
+	out.Body = ioutil.NopCloser(bytes.NewReader(body))
+	out.ContentLength = int64(len(body))
+
+	var buf bytes.Buffer
+	if err := out.Write(&buf); err != nil {
+		return err
+	}
+	return ioutil.WriteFile(filepath.Join(d.Dir, name), buf.Bytes(), 0644)
+}
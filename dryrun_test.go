@@ -0,0 +1,248 @@
+package hellosign
+
+import (
+	"archive/zip"
+	"bufio"
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDryRun(t *testing.T) {
+	assert := assert.New(t)
+
+	dir := t.TempDir()
+	dryRun := NewDryRun(dir)
+	client := Client{
+		APIKey:     "secret-key",
+		HTTPClient: &http.Client{Transport: failingTransport{}},
+		DryRun:     dryRun,
+	}
+
+	res, err := client.CreateSignatureRequest(CreationRequest{
+		TestMode: true,
+		File:     []string{"fixtures/offer_letter.pdf"},
+		Title:    "Offer letter",
+		Signers: []Signer{
+			{Name: "Jane Doe", Email: "jane@example.com"},
+			{Name: "John Doe", Email: "john@example.com", Order: 1},
+		},
+		CCEmailAddresses: []string{"hr@example.com"},
+		Metadata:         map[string]string{"employee_id": "42"},
+	})
+	assert.Nil(err)
+	assert.Len(res.SignatureRequestID, 40)
+	assert.True(res.TestMode)
+	assert.Equal("Offer letter", res.Title)
+	assert.Equal("42", res.Metadata["employee_id"])
+	assert.Equal("hr@example.com", *res.CCEmailAddress[0])
+	assert.Len(res.Signatures, 2)
+	assert.Equal("jane@example.com", res.Signatures[0].SignerEmailAddress)
+	assert.Equal(1, res.Signatures[1].Order)
+	assert.Equal(StatusAwaitingSignature, res.Signatures[1].StatusCode)
+	assert.Len(res.Signatures[0].SignatureID, 32)
+
+	got, err := client.GetSignatureRequest(res.SignatureRequestID)
+	assert.Nil(err)
+	assert.Equal(res.Signatures[1].SignatureID, got.Signatures[1].SignatureID)
+
+	reminded, err := client.RemindSignatureRequest(res.SignatureRequestID, "john@example.com")
+	assert.Nil(err)
+	assert.NotNil(reminded.Signatures[1].LastRemindedAt)
+
+	list, err := client.ListSignatureRequests()
+	assert.Nil(err)
+	assert.Equal(1, list.ListInfo.NumResults)
+
+	pdf, err := client.GetPDF(res.SignatureRequestID)
+	assert.Nil(err)
+	assert.Equal("%PDF-", string(pdf[:5]))
+
+	response, err := client.CancelSignatureRequest(res.SignatureRequestID)
+	assert.Nil(err)
+	assert.Equal(http.StatusOK, response.StatusCode)
+
+	_, err = client.GetSignatureRequest(res.SignatureRequestID)
+	apiErr, ok := err.(*APIError)
+	assert.True(ok)
+	assert.Equal(http.StatusGone, apiErr.StatusCode, "Canceled requests are kept, like deleted ones")
+
+	requests := dryRun.Requests()
+	assert.Len(requests, 7)
+	assert.Equal("POST", requests[0].Method)
+	assert.Equal("signature_request/send", requests[0].Path)
+	assert.Empty(requests[0].Header.Get("Authorization"))
+	assert.Contains(string(requests[0].Body), "Jane Doe")
+	assert.Equal("signature_request/list", requests[3].Path)
+
+	file, err := os.Open(filepath.Join(dir, "0001-POST-signature_request-send.http"))
+	assert.Nil(err)
+	defer file.Close()
+	replayed, err := http.ReadRequest(bufio.NewReader(file))
+	assert.Nil(err)
+	assert.Equal("/v3/signature_request/send", replayed.URL.Path)
+	assert.Empty(replayed.Header.Get("Authorization"))
+	assert.Nil(replayed.ParseMultipartForm(1 << 20))
+	assert.Equal("jane@example.com", replayed.FormValue("signers[0][email_address]"))
+}
+
+func TestDryRunJSONRequest(t *testing.T) {
+	assert := assert.New(t)
+
+	dryRun := NewDryRun("")
+	client := Client{APIKey: "secret-key", DryRun: dryRun}
+
+	res, err := client.CreateEmbeddedSignatureRequest(CreationRequest{
+		ClientID: "client-id",
+		FileURL:  []string{"https://example.com/offer_letter.pdf"},
+		Signers:  []Signer{{Name: "Jane Doe", Email: "jane@example.com"}},
+	})
+	assert.Nil(err)
+	assert.False(res.TestMode)
+	assert.Empty(res.SigningURL)
+	assert.Equal("Jane Doe", res.Signatures[0].SignerName)
+
+	updated, err := client.UpdateSignatureRequest(res.SignatureRequestID, res.Signatures[0].SignatureID, "jane.doe@example.com")
+	assert.Nil(err)
+	assert.Equal("jane.doe@example.com", updated.Signatures[0].SignerEmailAddress)
+
+	url, err := client.GetEmbeddedSignURL(res.Signatures[0].SignatureID)
+	assert.Nil(err)
+	assert.Contains(url.SignURL, res.Signatures[0].SignatureID)
+
+	requests := dryRun.Requests()
+	assert.Len(requests, 3)
+	assert.Equal(jsonContentType, requests[0].Header.Get("Content-Type"))
+}
+
+func TestDryRunRoutes(t *testing.T) {
+	assert := assert.New(t)
+
+	dryRun := NewDryRun("")
+	client := Client{APIKey: "secret-key", DryRun: dryRun}
+	res, err := client.CreateSignatureRequest(CreationRequest{
+		FileURL: []string{"https://example.com/offer_letter.pdf"},
+		Signers: []Signer{{Name: "Jane Doe", Email: "jane@example.com"}},
+	})
+	if !assert.Nil(err) {
+		return
+	}
+
+	// Updates and reminders only apply to POSTs, as with create; like unknown
+	// routes, other methods are not found.
+	for _, path := range []string{
+		"signature_request/update/" + res.SignatureRequestID,
+		"signature_request/remind/" + res.SignatureRequestID,
+		"signature_request/unknown",
+	} {
+		_, err := client.request("GET", path, &bytes.Buffer{}, "")
+		apiErr, ok := err.(*APIError)
+		if assert.True(ok, path) {
+			assert.Equal(http.StatusNotFound, apiErr.StatusCode, path)
+		}
+	}
+
+	_, err = client.GetPDF("missing")
+	apiErr, ok := err.(*APIError)
+	if assert.True(ok) {
+		assert.Equal(http.StatusNotFound, apiErr.StatusCode)
+	}
+
+	got, err := client.GetSignatureRequest(res.SignatureRequestID)
+	assert.Nil(err)
+	assert.Equal("jane@example.com", got.Signatures[0].SignerEmailAddress)
+	assert.Nil(got.Signatures[0].LastRemindedAt)
+
+	response, err := client.CancelSignatureRequest("missing")
+	assert.Nil(err)
+	assert.Equal(http.StatusNotFound, response.StatusCode)
+
+	response, err = client.CancelSignatureRequest(res.SignatureRequestID)
+	assert.Nil(err)
+	assert.Equal(http.StatusOK, response.StatusCode)
+	response, err = client.CancelSignatureRequest(res.SignatureRequestID)
+	assert.Nil(err)
+	assert.Equal(http.StatusNotFound, response.StatusCode, "Should not cancel twice")
+}
+
+func TestDryRunFiles(t *testing.T) {
+	assert := assert.New(t)
+
+	client := Client{APIKey: "secret-key", DryRun: NewDryRun("")}
+	files := []string{"fixtures/offer_letter.pdf", "fixtures/offer_letter_signed.pdf"}
+	res, err := client.CreateSignatureRequest(CreationRequest{
+		File:    files,
+		Signers: []Signer{{Name: "Jane Doe", Email: "jane@example.com"}},
+	})
+	if !assert.Nil(err) {
+		return
+	}
+
+	data, err := client.GetFiles(res.SignatureRequestID, "zip")
+	assert.Nil(err)
+	archive, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if assert.Nil(err) && assert.Len(archive.File, 2) {
+		assert.Equal("offer_letter.pdf", archive.File[0].Name)
+		assert.Equal("offer_letter_signed.pdf", archive.File[1].Name)
+	}
+
+	documents, err := client.GetDocuments(context.Background(), res.SignatureRequestID, files...)
+	if assert.Nil(err) && assert.Len(documents, 2) {
+		assert.Equal("fixtures/offer_letter_signed.pdf", documents[1].Upload)
+	}
+}
+
+func TestDryRunGroupSigners(t *testing.T) {
+	assert := assert.New(t)
+
+	client := Client{APIKey: "secret-key", DryRun: NewDryRun("")}
+	res, err := client.CreateSignatureRequest(CreationRequest{
+		FileURL: []string{"https://example.com/offer_letter.pdf"},
+		Signers: []Signer{{
+			Group:        "Legal",
+			GroupMembers: []Signer{{Name: "Jane Doe", Email: "jane@example.com"}, {Name: "John Doe", Email: "john@example.com"}},
+		}},
+	})
+	if assert.Nil(err) && assert.Len(res.Signatures, 1) {
+		assert.Equal("Legal", res.Signatures[0].SignerName)
+		assert.Equal("jane@example.com", res.Signatures[0].SignerEmailAddress)
+	}
+}
+
+func TestDryRunServeHTTP(t *testing.T) {
+	assert := assert.New(t)
+
+	dryRun := NewDryRun("")
+	server := httptest.NewServer(dryRun)
+	defer server.Close()
+
+	client := Client{APIKey: "secret-key", BaseURL: server.URL + "/v3/"}
+	res, err := client.CreateSignatureRequest(CreationRequest{
+		FileURL: []string{"https://example.com/offer_letter.pdf"},
+		Signers: []Signer{{Name: "Jane Doe", Email: "jane@example.com"}},
+	})
+	if !assert.Nil(err) {
+		return
+	}
+	assert.Empty(dryRun.Requests(), "Should only record Client requests")
+
+	assert.Nil(dryRun.Sign(res.SignatureRequestID, res.Signatures[0].SignatureID, map[string]interface{}{"agree": true}))
+	got, err := client.GetSignatureRequest(res.SignatureRequestID)
+	if assert.Nil(err) {
+		assert.True(got.IsComplete)
+		assert.Equal(StatusSigned, got.Signatures[0].StatusCode)
+	}
+	assert.NotNil(dryRun.Sign(res.SignatureRequestID, res.Signatures[0].SignatureID, nil), "Should not sign twice")
+
+	response, err := http.Get(server.URL + "/v3/unknown")
+	if assert.Nil(err) {
+		response.Body.Close()
+		assert.Equal(http.StatusNotFound, response.StatusCode)
+	}
+}
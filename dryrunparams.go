@@ -0,0 +1,190 @@
+package hellosign
+
+import (
+	"bytes"
+	"encoding/json"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// dryRunParams holds request parameters decoded from a query string or a
+// JSON, multipart or urlencoded body into the same nested shape: multipart
+// keys such as signers[0][email_address] become
+// {"signers": {"0": {"email_address": ...}}}. Uploaded files are stored as
+// their file names.
+type dryRunParams map[string]interface{}
+
+// parseDryRunParams decodes the parameters of a request. HelloSign ignores
+// GET bodies, so only the query string counts for them.
+func parseDryRunParams(method string, query url.Values, contentType string, body []byte) (dryRunParams, error) {
+	p := dryRunParams{}
+	if method == http.MethodGet {
+		p.setValues(query)
+		return p, nil
+	}
+
+	mediaType, options, _ := mime.ParseMediaType(contentType)
+	switch mediaType {
+	case jsonContentType:
+		if len(body) == 0 {
+			break
+		}
+		if err := json.Unmarshal(body, &p); err != nil {
+			return nil, err
+		}
+	case "multipart/form-data":
+		form, err := multipart.NewReader(bytes.NewReader(body), options["boundary"]).ReadForm(32 << 20)
+		if err != nil {
+			return nil, err
+		}
+		defer form.RemoveAll()
+
+		p.setValues(form.Value)
+		for key, files := range form.File {
+			p.set(key, files[0].Filename)
+		}
+	default:
+		values, err := url.ParseQuery(string(body))
+		if err != nil {
+			return nil, err
+		}
+		p.setValues(values)
+	}
+	return p, nil
+}
+
+func (p dryRunParams) setValues(values url.Values) {
+	for key, list := range values {
+		p.set(key, list[0])
+	}
+}
+
+func (p dryRunParams) set(key string, value interface{}) {
+	path := splitParamKey(key)
+	node := map[string]interface{}(p)
+	for _, part := range path[:len(path)-1] {
+		child, ok := node[part].(map[string]interface{})
+		if !ok {
+			child = map[string]interface{}{}
+			node[part] = child
+		}
+		node = child
+	}
+	node[path[len(path)-1]] = value
+}
+
+// splitParamKey splits signers[0][name] into signers, 0 and name.
+func splitParamKey(key string) []string {
+	open := strings.Index(key, "[")
+	if open < 0 {
+		return []string{key}
+	}
+
+	path := []string{key[:open]}
+	for _, part := range strings.Split(key[open+1:], "[") {
+		path = append(path, strings.TrimSuffix(part, "]"))
+	}
+	return path
+}
+
+func (p dryRunParams) str(key string) string {
+	return paramString(p[key])
+}
+
+func (p dryRunParams) boolean(key string) bool {
+	return paramBool(p[key])
+}
+
+func (p dryRunParams) list(key string) []interface{} {
+	return paramList(p[key])
+}
+
+func paramString(v interface{}) string {
+	switch value := v.(type) {
+	case nil:
+		return ""
+	case string:
+		return value
+	case float64:
+		return strconv.FormatFloat(value, 'f', -1, 64)
+	case bool:
+		return strconv.FormatBool(value)
+	}
+	data, _ := json.Marshal(v)
+	return string(data)
+}
+
+func paramBool(v interface{}) bool {
+	switch value := v.(type) {
+	case bool:
+		return value
+	case float64:
+		return value != 0
+	case string:
+		return value == "1" || value == "true"
+	}
+	return false
+}
+
+func paramInt(v interface{}) int {
+	switch value := v.(type) {
+	case float64:
+		return int(value)
+	case string:
+		i, _ := strconv.Atoi(value)
+		return i
+	}
+	return 0
+}
+
+// paramList accepts JSON arrays, maps keyed by index and JSON encoded strings.
+func paramList(v interface{}) []interface{} {
+	switch value := v.(type) {
+	case []interface{}:
+		return value
+	case map[string]interface{}:
+		return indexedParams(value)
+	case string:
+		list := []interface{}{}
+		if json.Unmarshal([]byte(value), &list) == nil {
+			return list
+		}
+	}
+	return nil
+}
+
+// paramMap accepts JSON objects and JSON encoded strings.
+func paramMap(v interface{}) map[string]interface{} {
+	switch value := v.(type) {
+	case map[string]interface{}:
+		return value
+	case string:
+		m := map[string]interface{}{}
+		if json.Unmarshal([]byte(value), &m) == nil {
+			return m
+		}
+	}
+	return nil
+}
+
+// indexedParams returns the values stored under numeric keys, in order.
+func indexedParams(m map[string]interface{}) []interface{} {
+	indexes := []int{}
+	for key := range m {
+		if i, err := strconv.Atoi(key); err == nil {
+			indexes = append(indexes, i)
+		}
+	}
+	sort.Ints(indexes)
+
+	values := make([]interface{}, 0, len(indexes))
+	for _, i := range indexes {
+		values = append(values, m[strconv.Itoa(i)])
+	}
+	return values
+}
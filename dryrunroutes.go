@@ -0,0 +1,397 @@
+package hellosign
+
+import (
+	"archive/zip"
+	"bytes"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"path"
+	"strings"
+	"time"
+)
+
+// respond answers an API call from the dry run's signature requests, as
+// HelloSign would. route is the path relative to the API base, e.g.
+// "signature_request/abc".
+func (d *DryRun) respond(method, route string, params dryRunParams, site dryRunSite) (int, string, []byte) {
+	action, id := route, ""
+	if i := strings.LastIndex(route, "/"); i >= 0 {
+		action, id = route[:i], route[i+1:]
+	}
+
+	switch {
+	case method == http.MethodPost && (route == "signature_request/send" || route == "signature_request/create_embedded"):
+		return d.create(params, site, route == "signature_request/send")
+	case method == http.MethodGet && route == "signature_request/list":
+		return d.list(params)
+	case method == http.MethodGet && action == "signature_request":
+		return d.get(id)
+	case method == http.MethodPost && action == "signature_request/update":
+		return d.update(id, params)
+	case method == http.MethodPost && action == "signature_request/remind":
+		return d.remind(id, params)
+	case method == http.MethodPost && action == "signature_request/cancel":
+		return d.cancel(id)
+	case method == http.MethodGet && action == "signature_request/files":
+		return d.files(id, params, site)
+	case method == http.MethodGet && action == "embedded/sign_url":
+		return d.signURL(id, site)
+	}
+	return dryRunNotFound()
+}
+
+func (d *DryRun) create(params dryRunParams, site dryRunSite, send bool) (int, string, []byte) {
+	signers := params.list("signers")
+	if len(signers) == 0 {
+		return dryRunError(http.StatusBadRequest, "bad_request", "Must specify a name for each signer")
+	}
+
+	documents := []string{}
+	for _, name := range params.list("file") {
+		documents = append(documents, paramString(name))
+	}
+	for _, url := range params.list("file_url") {
+		documents = append(documents, path.Base(paramString(url)))
+	}
+	if len(documents) == 0 {
+		return dryRunError(http.StatusBadRequest, "bad_request", "Must specify files or file_urls")
+	}
+
+	id := dryRunID(20)
+	request := &SignatureRequest{
+		TestMode:              params.boolean("test_mode"),
+		SignatureRequestID:    id,
+		RequesterEmailAddress: "dry-run@example.com",
+		Title:                 params.str("title"),
+		OriginalTitle:         params.str("title"),
+		Subject:               params.str("subject"),
+		Message:               params.str("message"),
+		Metadata:              paramMap(params["metadata"]),
+		CreatedAt:             Timestamp{Time: d.now()},
+		FilesURL:              site.api + "signature_request/files/" + id,
+		DetailsURL:            site.app + "home/manage?guid=" + id,
+		SigningRedirectURL:    params.str("signing_redirect_url"),
+		CustomFields:          []map[string]interface{}{},
+		ResponseData:          []*ResponseData{},
+		Signatures:            []*Signature{},
+	}
+	if request.Metadata == nil {
+		request.Metadata = map[string]interface{}{}
+	}
+	if send {
+		request.SigningURL = site.app + "sign/" + id
+	}
+	for _, cc := range params.list("cc_email_addresses") {
+		email := paramString(cc)
+		request.CCEmailAddress = append(request.CCEmailAddress, &email)
+	}
+	for _, field := range params.list("custom_fields") {
+		if m := paramMap(field); m != nil {
+			request.CustomFields = append(request.CustomFields, m)
+		}
+	}
+
+	for _, raw := range signers {
+		signer := paramMap(raw)
+		name, email := paramString(signer["name"]), paramString(signer["email_address"])
+		if group := paramString(signer["group"]); group != "" {
+			// JSON bodies list members under "signers", multipart bodies by index.
+			members := paramList(signer["signers"])
+			if members == nil {
+				members = indexedParams(signer)
+			}
+			name = group
+			if len(members) > 0 {
+				email = paramString(paramMap(members[0])["email_address"])
+			}
+		}
+		if name == "" {
+			return dryRunError(http.StatusBadRequest, "bad_request", "Must specify a name for each signer")
+		}
+
+		request.Signatures = append(request.Signatures, &Signature{
+			SignatureID:        dryRunID(16),
+			SignerEmailAddress: email,
+			SignerName:         name,
+			Order:              paramInt(signer["order"]),
+			StatusCode:         StatusAwaitingSignature,
+			HasPin:             paramString(signer["pin"]) != "",
+		})
+	}
+
+	d.mu.Lock()
+	if d.records == nil {
+		d.records = map[string]*dryRunRecord{}
+	}
+	d.records[id] = &dryRunRecord{request: request, documents: documents}
+	d.order = append(d.order, id)
+	response := copySignatureRequest(request)
+	d.mu.Unlock()
+
+	d.emit(EventSignatureRequestSent, response, "")
+	return dryRunJSON(&SignatureRequestResponse{SignatureRequest: response})
+}
+
+func (d *DryRun) get(id string) (int, string, []byte) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	record, ok := d.records[id]
+	switch {
+	case !ok:
+		return dryRunNotFound()
+	case record.canceled:
+		return dryRunDeleted()
+	}
+	return dryRunJSON(&SignatureRequestResponse{SignatureRequest: copySignatureRequest(record.request)})
+}
+
+func (d *DryRun) list(params dryRunParams) (int, string, []byte) {
+	requests := []*SignatureRequest{}
+	for _, request := range d.SignatureRequests() {
+		if matchesQuery(request, params.str("query")) {
+			requests = append(requests, request)
+		}
+	}
+
+	page, pageSize := paramInt(params["page"]), paramInt(params["page_size"])
+	if page < 1 {
+		page = 1
+	}
+	if pageSize < 1 {
+		pageSize = 20
+	}
+	start, end := (page-1)*pageSize, page*pageSize
+	if start > len(requests) {
+		start = len(requests)
+	}
+	if end > len(requests) {
+		end = len(requests)
+	}
+
+	return dryRunJSON(&ListResponse{
+		ListInfo: &ListInfo{
+			NumPages:   (len(requests) + pageSize - 1) / pageSize,
+			NumResults: len(requests),
+			Page:       page,
+			PageSize:   pageSize,
+		},
+		SignatureRequests: requests[start:end],
+	})
+}
+
+func (d *DryRun) update(id string, params dryRunParams) (int, string, []byte) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	record, ok := d.records[id]
+	switch {
+	case !ok:
+		return dryRunNotFound()
+	case record.canceled:
+		return dryRunDeleted()
+	}
+
+	for _, sig := range record.request.Signatures {
+		if sig.SignatureID == params.str("signature_id") {
+			sig.SignerEmailAddress = params.str("email_address")
+			return dryRunJSON(&SignatureRequestResponse{SignatureRequest: copySignatureRequest(record.request)})
+		}
+	}
+	return dryRunError(http.StatusBadRequest, "bad_request", "Invalid signature_id")
+}
+
+func (d *DryRun) remind(id string, params dryRunParams) (int, string, []byte) {
+	d.mu.Lock()
+	record, ok := d.records[id]
+	if !ok || record.canceled {
+		d.mu.Unlock()
+		return dryRunNotFound()
+	}
+
+	for _, sig := range record.request.Signatures {
+		if !strings.EqualFold(sig.SignerEmailAddress, params.str("email_address")) {
+			continue
+		}
+		if !sig.StatusCode.IsPending() {
+			d.mu.Unlock()
+			return dryRunError(http.StatusBadRequest, "bad_request", "This signer has already signed or declined")
+		}
+		now := Timestamp{Time: d.now()}
+		sig.LastRemindedAt = &now
+		request := copySignatureRequest(record.request)
+		d.mu.Unlock()
+
+		d.emit(EventSignatureRequestReminded, request, sig.SignatureID)
+		return dryRunJSON(&SignatureRequestResponse{SignatureRequest: request})
+	}
+	d.mu.Unlock()
+	return dryRunError(http.StatusBadRequest, "bad_request", "No signer with that email address")
+}
+
+func (d *DryRun) cancel(id string) (int, string, []byte) {
+	d.mu.Lock()
+	record, ok := d.records[id]
+	if !ok || record.canceled {
+		d.mu.Unlock()
+		return dryRunNotFound()
+	}
+	if record.request.IsComplete {
+		d.mu.Unlock()
+		return dryRunError(http.StatusBadRequest, "bad_request", "Cannot cancel a completed signature request")
+	}
+	record.canceled = true
+	request := copySignatureRequest(record.request)
+	d.mu.Unlock()
+
+	d.emit(EventSignatureRequestCanceled, request, "")
+	return http.StatusOK, jsonContentType, nil
+}
+
+// files returns the request as one PDF, or a zip holding a PDF per document
+// named after the uploaded file, as HelloSign converts documents to PDF.
+func (d *DryRun) files(id string, params dryRunParams, site dryRunSite) (int, string, []byte) {
+	d.mu.Lock()
+	record, ok := d.records[id]
+	ok = ok && !record.canceled
+	var documents []string
+	if ok {
+		documents = append(documents, record.documents...)
+	}
+	d.mu.Unlock()
+
+	if !ok {
+		return dryRunNotFound()
+	}
+
+	fileType := params.str("file_type")
+	if fileType == "" {
+		fileType = "pdf"
+	}
+	if fileType != "pdf" && fileType != "zip" {
+		return dryRunError(http.StatusBadRequest, "bad_request", "Invalid file_type")
+	}
+
+	if params.boolean("get_url") {
+		return dryRunJSON(map[string]interface{}{
+			"file_url":   site.app + "files/" + id + "." + fileType,
+			"expires_at": d.now().Add(time.Hour).Unix(),
+		})
+	}
+	if fileType == "pdf" {
+		return http.StatusOK, "application/pdf", dryRunPDF("signature request " + id)
+	}
+
+	var buf bytes.Buffer
+	archive := zip.NewWriter(&buf)
+	for _, name := range documents {
+		entry, err := archive.Create(strings.TrimSuffix(name, path.Ext(name)) + ".pdf")
+		if err != nil {
+			return dryRunError(http.StatusInternalServerError, "internal", err.Error())
+		}
+		entry.Write(dryRunPDF(name))
+	}
+	archive.Close()
+	return http.StatusOK, "application/zip", buf.Bytes()
+}
+
+func (d *DryRun) signURL(signatureID string, site dryRunSite) (int, string, []byte) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	for _, record := range d.records {
+		if record.canceled {
+			continue
+		}
+		for _, sig := range record.request.Signatures {
+			if sig.SignatureID == signatureID {
+				return dryRunJSON(&EmbeddedResponse{Embedded: &SignURLResponse{
+					SignURL:   site.app + "editor/embeddedSign?signature_id=" + signatureID + "&token=" + dryRunID(16),
+					ExpiresAt: Timestamp{Time: d.now().Add(time.Hour)},
+				}})
+			}
+		}
+	}
+	return dryRunNotFound()
+}
+
+// matchesQuery applies a small subset of the list search syntax: each space
+// separated term must match, either as field:value for title, subject, to and
+// metadata, or as a plain word found in the title, subject or a signer email.
+func matchesQuery(request *SignatureRequest, query string) bool {
+	for _, term := range strings.Fields(strings.ToLower(query)) {
+		field, value := "", term
+		if i := strings.Index(term, ":"); i >= 0 {
+			field, value = term[:i], strings.Trim(term[i+1:], `"`)
+		}
+
+		var candidates []string
+		if field == "" || field == "title" {
+			candidates = append(candidates, request.Title)
+		}
+		if field == "" || field == "subject" {
+			candidates = append(candidates, request.Subject)
+		}
+		if field == "" || field == "to" {
+			for _, sig := range request.Signatures {
+				candidates = append(candidates, sig.SignerEmailAddress)
+			}
+		}
+		if field == "metadata" {
+			for _, v := range request.Metadata {
+				candidates = append(candidates, paramString(v))
+			}
+		}
+
+		found := false
+		for _, candidate := range candidates {
+			if strings.Contains(strings.ToLower(candidate), value) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
+func dryRunJSON(v interface{}) (int, string, []byte) {
+	data, _ := json.Marshal(v)
+	return http.StatusOK, jsonContentType, data
+}
+
+func dryRunError(status int, name, message string) (int, string, []byte) {
+	_, contentType, data := dryRunJSON(&ErrorResponse{Error: &Error{Name: name, Message: message}})
+	return status, contentType, data
+}
+
+// dryRunNotFound is HelloSign's response for an unknown ID or route.
+func dryRunNotFound() (int, string, []byte) {
+	return dryRunError(http.StatusNotFound, "not_found", "Not found")
+}
+
+// dryRunDeleted is HelloSign's response for a canceled request.
+func dryRunDeleted() (int, string, []byte) {
+	return dryRunError(http.StatusGone, "deleted", "This resource has been deleted")
+}
+
+func dryRunID(size int) string {
+	b := make([]byte, size)
+	rand.Read(b)
+	return hex.EncodeToString(b)
+}
+
+func dryRunPDF(name string) []byte {
+	return []byte("%PDF-1.4\n% dry run document " + name + "\n%%EOF\n")
+}
+
+func copySignatureRequest(request *SignatureRequest) *SignatureRequest {
+	data, _ := json.Marshal(request)
+	copied := &SignatureRequest{}
+	json.Unmarshal(data, copied)
+	return copied
+}
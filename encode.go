@@ -0,0 +1,256 @@
+package hellosign
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Struct tags understood by the encoder. Top level request fields use
+// form_field, nested structs use field. Both accept the same options:
+//
+//	omitempty - skip zero values
+//	json      - write the value as a single JSON encoded field
+//	file      - treat strings as local paths and upload their contents
+//
+// A tag of "-" or a missing tag skips the field.
+const (
+	formFieldTag   = "form_field"
+	nestedFieldTag = "field"
+)
+
+// formMarshaler is implemented by types that need a custom multipart representation.
+type formMarshaler interface {
+	marshalForm(e *formEncoder, name string) error
+}
+
+type tagOptions struct {
+	omitEmpty bool
+	json      bool
+	file      bool
+}
+
+func parseTag(field reflect.StructField) (string, tagOptions, bool) {
+	tag, ok := field.Tag.Lookup(formFieldTag)
+	if !ok {
+		tag, ok = field.Tag.Lookup(nestedFieldTag)
+	}
+	if !ok || tag == "-" {
+		return "", tagOptions{}, false
+	}
+
+	parts := strings.Split(tag, ",")
+	opts := tagOptions{}
+	for _, opt := range parts[1:] {
+		switch opt {
+		case "omitempty":
+			opts.omitEmpty = true
+		case "json":
+			opts.json = true
+		case "file":
+			opts.file = true
+		}
+	}
+	return parts[0], opts, parts[0] != ""
+}
+
+// formEncoder writes Go values as multipart form fields, naming nested values
+// the way HelloSign expects: signers[0][email_address], metadata[key], file[1].
+type formEncoder struct {
+	w *multipart.Writer
+}
+
+func newFormEncoder(w *multipart.Writer) *formEncoder {
+	return &formEncoder{w: w}
+}
+
+// marshalMultipart encodes any tagged request struct into a multipart body.
+func marshalMultipart(v interface{}) (*bytes.Buffer, *multipart.Writer, error) {
+	var b bytes.Buffer
+	w := multipart.NewWriter(&b)
+
+	if err := newFormEncoder(w).Encode(v); err != nil {
+		return nil, nil, err
+	}
+
+	if err := w.Close(); err != nil {
+		return nil, nil, err
+	}
+	return &b, w, nil
+}
+
+// Encode writes the tagged fields of the struct v.
+func (e *formEncoder) Encode(v interface{}) error {
+	val := reflect.ValueOf(v)
+	for val.Kind() == reflect.Ptr {
+		if val.IsNil() {
+			return nil
+		}
+		val = val.Elem()
+	}
+	if val.Kind() != reflect.Struct {
+		return fmt.Errorf("hellosign: cannot encode %s as a form", val.Type())
+	}
+	return e.encodeStruct("", val)
+}
+
+func (e *formEncoder) encodeStruct(prefix string, val reflect.Value) error {
+	structType := val.Type()
+
+	for i := 0; i < val.NumField(); i++ {
+		field := structType.Field(i)
+		if field.PkgPath != "" {
+			continue
+		}
+
+		tag, opts, ok := parseTag(field)
+		if !ok {
+			continue
+		}
+
+		name := tag
+		if prefix != "" {
+			name = fmt.Sprintf("%s[%s]", prefix, tag)
+		}
+
+		if err := e.encodeValue(name, val.Field(i), opts); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (e *formEncoder) encodeValue(name string, val reflect.Value, opts tagOptions) error {
+	if opts.omitEmpty && isEmptyValue(val) {
+		return nil
+	}
+
+	if (val.Kind() == reflect.Ptr || val.Kind() == reflect.Interface) && val.IsNil() {
+		return nil
+	}
+
+	if opts.json {
+		data, err := json.Marshal(val.Interface())
+		if err != nil {
+			return err
+		}
+		return e.WriteField(name, string(data))
+	}
+
+	switch v := val.Interface().(type) {
+	case formMarshaler:
+		return v.marshalForm(e, name)
+	case io.Reader:
+		return e.WriteReader(name, v)
+	}
+
+	switch val.Kind() {
+	case reflect.Ptr, reflect.Interface:
+		return e.encodeValue(name, val.Elem(), opts)
+	case reflect.Struct:
+		return e.encodeStruct(name, val)
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < val.Len(); i++ {
+			if err := e.encodeValue(fmt.Sprintf("%s[%v]", name, i), val.Index(i), opts); err != nil {
+				return err
+			}
+		}
+		return nil
+	case reflect.Map:
+		keys := val.MapKeys()
+		sort.Slice(keys, func(i, j int) bool {
+			return fmt.Sprint(keys[i].Interface()) < fmt.Sprint(keys[j].Interface())
+		})
+		for _, key := range keys {
+			if err := e.encodeValue(fmt.Sprintf("%s[%v]", name, key.Interface()), val.MapIndex(key), opts); err != nil {
+				return err
+			}
+		}
+		return nil
+	case reflect.Bool:
+		return e.WriteField(name, boolToIntString(val.Bool()))
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return e.WriteField(name, strconv.FormatInt(val.Int(), 10))
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return e.WriteField(name, strconv.FormatUint(val.Uint(), 10))
+	case reflect.Float32, reflect.Float64:
+		return e.WriteField(name, strconv.FormatFloat(val.Float(), 'f', -1, 64))
+	case reflect.String:
+		if opts.file {
+			return e.WriteFile(name, val.String())
+		}
+		return e.WriteField(name, val.String())
+	}
+
+	return fmt.Errorf("hellosign: cannot encode %s of type %s", name, val.Type())
+}
+
+// WriteField writes a single form value.
+func (e *formEncoder) WriteField(name, value string) error {
+	formField, err := e.w.CreateFormField(name)
+	if err != nil {
+		return err
+	}
+	_, err = formField.Write([]byte(value))
+	return err
+}
+
+// WriteFile uploads the file at path.
+func (e *formEncoder) WriteFile(name, path string) error {
+	file, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	return e.WriteReader(name, file)
+}
+
+// WriteReader uploads the reader contents. Readers with a Name method
+// (such as *os.File) keep their base file name.
+func (e *formEncoder) WriteReader(name string, r io.Reader) error {
+	filename := name
+	if named, ok := r.(interface{ Name() string }); ok {
+		filename = filepath.Base(named.Name())
+	}
+
+	formFile, err := e.w.CreateFormFile(name, filename)
+	if err != nil {
+		return err
+	}
+	_, err = io.Copy(formFile, r)
+	return err
+}
+
+func isEmptyValue(v reflect.Value) bool {
+	switch v.Kind() {
+	case reflect.Array, reflect.Map, reflect.Slice, reflect.String:
+		return v.Len() == 0
+	case reflect.Bool:
+		return !v.Bool()
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return v.Int() == 0
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return v.Uint() == 0
+	case reflect.Float32, reflect.Float64:
+		return v.Float() == 0
+	case reflect.Interface, reflect.Ptr:
+		return v.IsNil()
+	}
+	return false
+}
+
+func boolToIntString(value bool) string {
+	if value {
+		return "1"
+	}
+	return "0"
+}
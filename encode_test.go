@@ -0,0 +1,181 @@
+package hellosign
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"mime"
+	"mime/multipart"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type encodeTestItem struct {
+	Label   string `field:"label"`
+	Count   int    `field:"count,omitempty"`
+	Enabled bool   `field:"enabled"`
+}
+
+type encodeTestRequest struct {
+	Title    string            `form_field:"title,omitempty"`
+	Note     string            `form_field:"note,omitempty"`
+	Ratio    float64           `form_field:"ratio"`
+	Items    []encodeTestItem  `form_field:"items"`
+	Nested   *encodeTestItem   `form_field:"nested"`
+	Missing  *encodeTestItem   `form_field:"missing"`
+	Tags     map[string]string `form_field:"tags"`
+	Payload  []encodeTestItem  `form_field:"payload,json"`
+	Upload   *strings.Reader   `form_field:"upload"`
+	Skipped  string            `form_field:"-"`
+	Untagged string
+}
+
+func readMultipart(t *testing.T, v interface{}) *multipart.Form {
+	params, writer, err := marshalMultipart(v)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	form, err := multipart.NewReader(params, writer.Boundary()).ReadForm(1 << 20)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return form
+}
+
+func TestFormEncoder(t *testing.T) {
+	assert := assert.New(t)
+
+	form := readMultipart(t, encodeTestRequest{
+		Title: "Contract",
+		Ratio: 0.5,
+		Items: []encodeTestItem{
+			{Label: "first", Count: 2, Enabled: true},
+			{Label: "second"},
+		},
+		Nested:   &encodeTestItem{Label: "inner"},
+		Tags:     map[string]string{"b": "2", "a": "1"},
+		Payload:  []encodeTestItem{{Label: "json"}},
+		Upload:   strings.NewReader("file contents"),
+		Skipped:  "no",
+		Untagged: "no",
+	})
+
+	assert.Equal(map[string][]string{
+		"title":             {"Contract"},
+		"ratio":             {"0.5"},
+		"items[0][label]":   {"first"},
+		"items[0][count]":   {"2"},
+		"items[0][enabled]": {"1"},
+		"items[1][label]":   {"second"},
+		"items[1][enabled]": {"0"},
+		"nested[label]":     {"inner"},
+		"nested[enabled]":   {"0"},
+		"tags[a]":           {"1"},
+		"tags[b]":           {"2"},
+		"payload":           {`[{"Label":"json","Count":0,"Enabled":false}]`},
+	}, form.Value)
+
+	if assert.Equal(1, len(form.File["upload"])) {
+		file, _ := form.File["upload"][0].Open()
+		data, _ := ioutil.ReadAll(file)
+		assert.Equal("file contents", string(data))
+	}
+}
+
+// encodeRequest encodes request as a create does, once as JSON with
+// file_url documents and once as multipart/form-data with uploaded files, and
+// decodes both bodies.
+func encodeRequest(t *testing.T, request CreationRequest) (map[string]interface{}, *multipart.Form) {
+	byURL, upload := request, request
+	byURL.File, byURL.FileURL = nil, nil
+	upload.File, upload.FileURL = nil, nil
+	for range append(request.File, request.FileURL...) {
+		byURL.FileURL = append(byURL.FileURL, "https://example.com/offer_letter.pdf")
+		upload.File = append(upload.File, "fixtures/offer_letter.pdf")
+	}
+
+	client := Client{}
+	body, contentType, err := client.marshalRequest(byURL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if contentType != jsonContentType {
+		t.Fatalf("content type %q, want JSON", contentType)
+	}
+	object := map[string]interface{}{}
+	if err := json.Unmarshal(body.Bytes(), &object); err != nil {
+		t.Fatal(err)
+	}
+
+	body, contentType, err = client.marshalRequest(upload)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, params, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		t.Fatal(err)
+	}
+	form, err := multipart.NewReader(body, params["boundary"]).ReadForm(1 << 20)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return object, form
+}
+
+// jsonValue re-encodes a decoded JSON value for comparison, with object keys sorted.
+func jsonValue(value interface{}) string {
+	data, _ := json.Marshal(value)
+	return string(data)
+}
+
+func TestMarshalRequestCreationRequest(t *testing.T) {
+	assert := assert.New(t)
+
+	request := creationRequest()
+	request.Attachments = []Attachment{{Name: "ID", SignerIndex: 1, Required: true}}
+
+	object, form := encodeRequest(t, request)
+
+	assert.Equal("1", form.Value["test_mode"][0])
+	assert.Equal("0", form.Value["use_text_tags"][0])
+	assert.Equal("freddy@hellosign.com", form.Value["signers[0][email_address]"][0])
+	assert.Equal("Frederick Rangel", form.Value["signers[1][name]"][0])
+	assert.Nil(form.Value["signers[0][order]"])
+	assert.Nil(form.Value["signers[0][pin]"])
+	assert.Equal("no@dogs.com", form.Value["cc_email_addresses[1]"][0])
+	assert.Equal("cats", form.Value["metadata[no]"][0])
+	assert.Equal("ID", form.Value["attachments[0][name]"][0])
+	assert.Equal("1", form.Value["attachments[0][signer_index]"][0])
+	assert.Equal("1", form.Value["attachments[0][required]"][0])
+	assert.Nil(form.Value["attachments[0][instructions]"])
+	assert.Contains(form.Value["form_fields_per_document"][0], `"api_id":"api_id_2"`)
+	assert.Nil(form.Value["signing_redirect_url"])
+	assert.Nil(form.Value["form_field_groups"])
+
+	assert.Equal(2, len(form.File["file[0]"])+len(form.File["file[1]"]))
+	assert.Equal("offer_letter.pdf", form.File["file[0]"][0].Filename)
+
+	assert.Equal(true, object["test_mode"])
+	assert.Equal(false, object["use_text_tags"])
+	assert.Equal(`[{"email_address":"freddy@hellosign.com","name":"Freddy Rangel"},{"email_address":"frederick.rangel@gmail.com","name":"Frederick Rangel"}]`,
+		jsonValue(object["signers"]))
+	assert.Equal(`["no@cats.com","no@dogs.com"]`, jsonValue(object["cc_email_addresses"]))
+	assert.Equal(`{"more":"dogs","no":"cats"}`, jsonValue(object["metadata"]))
+	assert.Equal(`[{"name":"ID","required":true,"signer_index":1}]`, jsonValue(object["attachments"]))
+	assert.Contains(jsonValue(object["form_fields_per_document"]), `"api_id":"api_id_2"`)
+	assert.NotContains(object, "signing_redirect_url")
+	assert.NotContains(object, "form_field_groups")
+	assert.Len(object["file_url"], 2)
+}
+
+func TestMarshalRequestMissingFile(t *testing.T) {
+	request := creationRequest()
+	request.File = []string{"fixtures/missing.pdf", "fixtures/missing.pdf"}
+
+	client := Client{}
+	_, _, err := client.marshalRequest(request)
+
+	assert.NotNil(t, err, "Should return error")
+}
@@ -0,0 +1,171 @@
+package hellosign
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+)
+
+const jsonContentType = "application/json"
+
+// errUploadRequired is returned by the JSON encoder when a value contains
+// file contents, which can only be sent as multipart/form-data.
+var errUploadRequired = errors.New("hellosign: request contains file uploads")
+
+// jsonBodyMarshaler is implemented by types whose JSON body differs from
+// what their field tags describe. name is the value's multipart field name,
+// e.g. signers[1], for use in error messages.
+type jsonBodyMarshaler interface {
+	marshalBody(e *jsonEncoder, name string) (interface{}, error)
+}
+
+// jsonEncoder converts tagged request structs into JSON objects using the
+// same form_field and field tags as formEncoder. Values tagged json are
+// embedded as-is instead of being encoded as strings.
+type jsonEncoder struct{}
+
+// marshalBody encodes v as JSON when possible and falls back to multipart
+// when it contains file uploads. It returns the body and its content type.
+func marshalBody(v interface{}) (*bytes.Buffer, string, error) {
+	data, err := marshalJSON(v)
+	if err == nil {
+		return bytes.NewBuffer(data), jsonContentType, nil
+	}
+	if err != errUploadRequired {
+		return nil, "", err
+	}
+
+	params, writer, err := marshalMultipart(v)
+	if err != nil {
+		return nil, "", err
+	}
+	return params, writer.FormDataContentType(), nil
+}
+
+// marshalJSON encodes any tagged request struct into a JSON body.
+func marshalJSON(v interface{}) ([]byte, error) {
+	val := reflect.ValueOf(v)
+	for val.Kind() == reflect.Ptr {
+		if val.IsNil() {
+			return []byte("{}"), nil
+		}
+		val = val.Elem()
+	}
+	if val.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("hellosign: cannot encode %s as a JSON body", val.Type())
+	}
+
+	e := &jsonEncoder{}
+	body, err := e.encodeStruct("", val)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(body)
+}
+
+func (e *jsonEncoder) encodeStruct(prefix string, val reflect.Value) (map[string]interface{}, error) {
+	structType := val.Type()
+	object := map[string]interface{}{}
+
+	for i := 0; i < val.NumField(); i++ {
+		field := structType.Field(i)
+		if field.PkgPath != "" {
+			continue
+		}
+
+		tag, opts, ok := parseTag(field)
+		if !ok {
+			continue
+		}
+
+		name := tag
+		if prefix != "" {
+			name = fmt.Sprintf("%s[%s]", prefix, tag)
+		}
+
+		value, keep, err := e.encodeValue(name, val.Field(i), opts)
+		if err != nil {
+			return nil, err
+		}
+		if keep {
+			object[tag] = value
+		}
+	}
+	return object, nil
+}
+
+// encodeValue returns the JSON representation of val and whether it should
+// be included at all. Values the multipart encoder would not write are dropped.
+// name is the multipart field name of val, used to label errors.
+func (e *jsonEncoder) encodeValue(name string, val reflect.Value, opts tagOptions) (interface{}, bool, error) {
+	if opts.omitEmpty && isEmptyValue(val) {
+		return nil, false, nil
+	}
+
+	switch val.Kind() {
+	case reflect.Ptr, reflect.Interface:
+		if val.IsNil() {
+			return nil, false, nil
+		}
+	case reflect.Slice, reflect.Map:
+		if val.Len() == 0 {
+			return nil, false, nil
+		}
+	}
+
+	if opts.json {
+		return val.Interface(), true, nil
+	}
+
+	switch v := val.Interface().(type) {
+	case jsonBodyMarshaler:
+		value, err := v.marshalBody(e, name)
+		return value, err == nil, err
+	case io.Reader:
+		return nil, false, errUploadRequired
+	}
+
+	switch val.Kind() {
+	case reflect.Ptr, reflect.Interface:
+		return e.encodeValue(name, val.Elem(), opts)
+	case reflect.Struct:
+		object, err := e.encodeStruct(name, val)
+		return object, err == nil, err
+	case reflect.Slice, reflect.Array:
+		list := make([]interface{}, 0, val.Len())
+		for i := 0; i < val.Len(); i++ {
+			value, _, err := e.encodeValue(fmt.Sprintf("%s[%v]", name, i), val.Index(i), opts)
+			if err != nil {
+				return nil, false, err
+			}
+			list = append(list, value)
+		}
+		return list, true, nil
+	case reflect.Map:
+		object := map[string]interface{}{}
+		for _, key := range val.MapKeys() {
+			value, keep, err := e.encodeValue(fmt.Sprintf("%s[%v]", name, key.Interface()), val.MapIndex(key), opts)
+			if err != nil {
+				return nil, false, err
+			}
+			if keep {
+				object[fmt.Sprint(key.Interface())] = value
+			}
+		}
+		return object, true, nil
+	case reflect.String:
+		if opts.file {
+			return nil, false, errUploadRequired
+		}
+		return val.String(), true, nil
+	case reflect.Bool, reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return val.Interface(), true, nil
+	}
+
+	return nil, false, fmt.Errorf("hellosign: cannot encode value of type %s", val.Type())
+}
@@ -0,0 +1,99 @@
+package hellosign
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMarshalBodyUsesJSONWithoutFiles(t *testing.T) {
+	assert := assert.New(t)
+
+	request := CreationRequest{
+		TestMode: true,
+		FileURL:  []string{"http://www.pdf995.com/samples/pdf.pdf"},
+		Title:    "My First Document",
+		Signers: []Signer{
+			{Email: "jane@example.com", Name: "Jane Doe"},
+			{Group: "Legal", Order: 1, GroupMembers: []Signer{{Email: "a@example.com", Name: "Alice"}}},
+		},
+		Metadata:              map[string]string{"no": "cats"},
+		FormFieldsPerDocument: [][]DocumentFormField{{{APIId: "name", Type: FieldTypeText}}},
+		SigningOptions:        &SigningOptions{Draw: true, DefaultType: SigningTypeDraw},
+	}
+
+	params, contentType, err := marshalBody(request)
+	assert.Nil(err, "Should not return error")
+	assert.Equal("application/json", contentType)
+
+	body := map[string]interface{}{}
+	assert.Nil(json.Unmarshal(params.Bytes(), &body))
+
+	assert.Equal(true, body["test_mode"])
+	assert.Equal(false, body["use_text_tags"])
+	assert.Equal("My First Document", body["title"])
+	assert.Equal([]interface{}{"http://www.pdf995.com/samples/pdf.pdf"}, body["file_url"])
+	assert.Equal(map[string]interface{}{"no": "cats"}, body["metadata"])
+	assert.Equal([]interface{}{
+		map[string]interface{}{"email_address": "jane@example.com", "name": "Jane Doe"},
+		map[string]interface{}{
+			"group": "Legal",
+			"order": float64(1),
+			"signers": []interface{}{
+				map[string]interface{}{"email_address": "a@example.com", "name": "Alice"},
+			},
+		},
+	}, body["signers"])
+	assert.Equal("text", body["form_fields_per_document"].([]interface{})[0].([]interface{})[0].(map[string]interface{})["type"])
+	assert.Equal("draw", body["signing_options"].(map[string]interface{})["default_type"])
+
+	for _, key := range []string{"file", "client_id", "subject", "attachments", "form_field_groups", "field_options"} {
+		_, ok := body[key]
+		assert.False(ok, key)
+	}
+}
+
+func TestMarshalBodyUsesMultipartForFiles(t *testing.T) {
+	assert := assert.New(t)
+
+	params, contentType, err := marshalBody(creationRequest())
+	assert.Nil(err, "Should not return error")
+	assert.True(strings.HasPrefix(contentType, "multipart/form-data; boundary="))
+	assert.Contains(params.String(), `name="signers[0][email_address]"`)
+	assert.Contains(params.String(), `filename="offer_letter.pdf"`)
+
+	params, contentType, err = marshalBody(encodeTestRequest{Upload: strings.NewReader("data")})
+	assert.Nil(err, "Should not return error")
+	assert.True(strings.HasPrefix(contentType, "multipart/form-data"))
+}
+
+func TestMarshalBodySignerErrors(t *testing.T) {
+	assert := assert.New(t)
+
+	request := CreationRequest{
+		FileURL: []string{"http://www.pdf995.com/samples/pdf.pdf"},
+		Signers: []Signer{
+			{Email: "jane@example.com", Name: "Jane Doe"},
+			{Email: "john@example.com", Name: "John Doe", SMSPhoneNumberType: "voice"},
+		},
+	}
+	_, _, err := marshalBody(request)
+	assert.EqualError(err, `signers[1]: unknown sms_phone_number_type "voice"`)
+
+	request.Signers[1] = Signer{GroupMembers: []Signer{{Email: "a@example.com", Name: "Alice"}}}
+	_, _, err = marshalBody(request)
+	assert.EqualError(err, "signers[1]: group name is required for group signers")
+
+	request.Signers[1] = Signer{Group: "Legal", GroupMembers: []Signer{
+		{Email: "a@example.com", Name: "Alice"},
+		{GroupMembers: []Signer{{Email: "b@example.com", Name: "Bob"}}},
+	}}
+	_, _, err = marshalBody(request)
+	assert.EqualError(err, "signers[1][1]: signer groups cannot be nested")
+
+	request.Signers[1].GroupMembers[1] = Signer{Email: "b@example.com", Name: "Bob", SMSPhoneNumberType: "sms"}
+	_, _, err = marshalBody(request)
+	assert.EqualError(err, `signers[1][1]: unknown sms_phone_number_type "sms"`)
+}
@@ -0,0 +1,77 @@
+package hellosign
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"net/url"
+	"strings"
+)
+
+// Callback event types.
+const (
+	EventCallbackTest              = "callback_test"
+	EventSignatureRequestViewed    = "signature_request_viewed"
+	EventSignatureRequestSent      = "signature_request_sent"
+	EventSignatureRequestSigned    = "signature_request_signed"
+	EventSignatureRequestAllSigned = "signature_request_all_signed"
+	EventSignatureRequestDeclined  = "signature_request_declined"
+	EventSignatureRequestReminded  = "signature_request_remind"
+	EventSignatureRequestCanceled  = "signature_request_canceled"
+)
+
+// EventReceived is the body a callback handler must respond with for HelloSign
+// to consider the event delivered.
+const EventReceived = "Hello API Event Received"
+
+// Event is the payload HelloSign posts to callback URLs in the "json" form field.
+type Event struct {
+	Event            EventInfo         `json:"event"`
+	SignatureRequest *SignatureRequest `json:"signature_request"`
+}
+
+// EventInfo describes a callback event.
+type EventInfo struct {
+	EventTime     string                 `json:"event_time"`
+	EventType     string                 `json:"event_type"`
+	EventHash     string                 `json:"event_hash"`
+	EventMetadata map[string]interface{} `json:"event_metadata"`
+}
+
+// EventHash returns the hex HMAC-SHA256 of event_time and event_type keyed with
+// the API key, which HelloSign uses to sign callback events.
+func EventHash(apiKey, eventTime, eventType string) string {
+	mac := hmac.New(sha256.New, []byte(apiKey))
+	mac.Write([]byte(eventTime + eventType))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// ParseEvent decodes a callback body, either the JSON payload itself or the
+// urlencoded form HelloSign posts with the payload in its "json" field.
+func ParseEvent(data []byte) (*Event, error) {
+	body := strings.TrimSpace(string(data))
+	if !strings.HasPrefix(body, "{") {
+		values, err := url.ParseQuery(body)
+		if err != nil {
+			return nil, err
+		}
+		body = values.Get("json")
+		if body == "" {
+			return nil, errors.New("callback body has no json field")
+		}
+	}
+
+	event := &Event{}
+	if err := json.Unmarshal([]byte(body), event); err != nil {
+		return nil, err
+	}
+	return event, nil
+}
+
+// Verify reports whether the event hash was signed with apiKey.
+func (e *Event) Verify(apiKey string) bool {
+	expected := EventHash(apiKey, e.Event.EventTime, e.Event.EventType)
+	return hmac.Equal([]byte(expected), []byte(e.Event.EventHash))
+}
@@ -0,0 +1,37 @@
+package hellosign
+
+import (
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseEvent(t *testing.T) {
+	assert := assert.New(t)
+
+	payload := `{"event":{"event_time":"1348177752","event_type":"signature_request_signed","event_hash":"` +
+		EventHash("secret", "1348177752", EventSignatureRequestSigned) +
+		`","event_metadata":{"related_signature_id":"abc"}},"signature_request":{"signature_request_id":"xyz"}}`
+
+	for _, body := range []string{payload, url.Values{"json": {payload}}.Encode()} {
+		event, err := ParseEvent([]byte(body))
+		if !assert.Nil(err, "Should not return error") {
+			continue
+		}
+		assert.Equal(EventSignatureRequestSigned, event.Event.EventType)
+		assert.Equal("abc", event.Event.EventMetadata["related_signature_id"])
+		assert.Equal("xyz", event.SignatureRequest.SignatureRequestID)
+		assert.True(event.Verify("secret"))
+		assert.False(event.Verify("other"))
+	}
+
+	_, err := ParseEvent([]byte("foo=bar"))
+	assert.Equal("callback body has no json field", err.Error())
+}
+
+func TestListOptionsValues(t *testing.T) {
+	assert.Equal(t, "", ListOptions{}.values().Encode())
+	assert.Equal(t, "account_id=all&page=2&page_size=50&query=title%3AOffer",
+		ListOptions{AccountID: "all", Page: 2, PageSize: 50, Query: "title:Offer"}.values().Encode())
+}
@@ -0,0 +1,174 @@
+package hellosign
+
+import (
+	"fmt"
+)
+
+// Requirements for a FieldGroup.
+const (
+	GroupRequireZeroOrOne = "require_0-1"
+	GroupRequireOne       = "require_1"
+	GroupRequireOneOrMore = "require_1-ormore"
+)
+
+// Operators for a RuleTrigger.
+const (
+	TriggerIs    = "is"
+	TriggerNot   = "not"
+	TriggerMatch = "match"
+	TriggerAny   = "any"
+	TriggerNone  = "none"
+)
+
+// Types for a RuleAction.
+const (
+	ActionChangeFieldVisibility = "change-field-visibility"
+	ActionChangeGroupVisibility = "change-group-visibility"
+)
+
+// Trigger values for checkbox fields.
+const (
+	TriggerValueChecked   = "1"
+	TriggerValueUnchecked = "0"
+)
+
+// FieldGroup places a requirement on the form fields sharing its ID in their Group attribute.
+type FieldGroup struct {
+	ID          string `json:"group_id"`    // The group referenced by DocumentFormField.Group.
+	Label       string `json:"group_label"` // Name of the group shown to the signer.
+	Requirement string `json:"requirement"` // How many fields in the group must be filled in. See the GroupRequire* constants.
+}
+
+// FieldRule shows or hides fields or groups when its triggers match.
+type FieldRule struct {
+	ID              string        `json:"id"`               // A unique id for the rule.
+	TriggerOperator string        `json:"trigger_operator"` // How triggers are combined. Only "AND" is currently supported.
+	Triggers        []RuleTrigger `json:"triggers"`         // Conditions on other fields. Only one trigger is currently supported.
+	Actions         []RuleAction  `json:"actions"`          // What happens when the triggers match.
+}
+
+// RuleTrigger matches the value of a form field.
+type RuleTrigger struct {
+	ID       string   `json:"id"`               // The api_id of the field being watched.
+	Operator string   `json:"operator"`         // See the Trigger* constants.
+	Value    string   `json:"value,omitempty"`  // Compared value for the is, not and match operators.
+	Values   []string `json:"values,omitempty"` // Compared values for the any and none operators.
+}
+
+// RuleAction changes the visibility of a field or group.
+type RuleAction struct {
+	FieldID string `json:"field_id,omitempty"` // The api_id of the field to change. Used with change-field-visibility.
+	GroupID string `json:"group_id,omitempty"` // The id of the group to change. Used with change-group-visibility.
+	Hidden  bool   `json:"hidden"`             // Whether the target becomes hidden when the rule matches.
+	Type    string `json:"type"`               // See the ActionChange* constants.
+}
+
+func (r CreationRequest) validateFormFields() error {
+	if err := validateFormFieldsPerDocument(r.FormFieldsPerDocument); err != nil {
+		return err
+	}
+	if err := validateFieldGroups(r.FormFieldsPerDocument, r.FormFieldGroups); err != nil {
+		return err
+	}
+	return validateFieldRules(r.FormFieldsPerDocument, r.FormFieldGroups, r.FormFieldRules)
+}
+
+func validateFieldGroups(documents [][]DocumentFormField, groups []FieldGroup) error {
+	used := map[string]bool{}
+	for _, fields := range documents {
+		for _, field := range fields {
+			if field.Group != "" {
+				used[field.Group] = true
+			}
+		}
+	}
+
+	seen := map[string]bool{}
+	for i, group := range groups {
+		if group.ID == "" {
+			return fmt.Errorf("form_field_groups[%d]: group_id is required", i)
+		}
+		if seen[group.ID] {
+			return fmt.Errorf("form_field_groups[%d]: duplicate group_id %q", i, group.ID)
+		}
+		seen[group.ID] = true
+
+		switch group.Requirement {
+		case GroupRequireZeroOrOne, GroupRequireOne, GroupRequireOneOrMore:
+		default:
+			return fmt.Errorf("form_field_groups[%d]: unknown requirement %q", i, group.Requirement)
+		}
+		if !used[group.ID] {
+			return fmt.Errorf("form_field_groups[%d]: no form field belongs to group %q", i, group.ID)
+		}
+	}
+	return nil
+}
+
+func validateFieldRules(documents [][]DocumentFormField, groups []FieldGroup, rules []FieldRule) error {
+	fieldIDs := map[string]bool{}
+	for _, fields := range documents {
+		for _, field := range fields {
+			fieldIDs[field.APIId] = true
+		}
+	}
+	groupIDs := map[string]bool{}
+	for _, group := range groups {
+		groupIDs[group.ID] = true
+	}
+
+	seen := map[string]bool{}
+	for i, rule := range rules {
+		if rule.ID == "" {
+			return fmt.Errorf("form_field_rules[%d]: id is required", i)
+		}
+		if seen[rule.ID] {
+			return fmt.Errorf("form_field_rules[%d]: duplicate id %q", i, rule.ID)
+		}
+		seen[rule.ID] = true
+
+		if rule.TriggerOperator != "AND" {
+			return fmt.Errorf("form_field_rules[%d]: unsupported trigger_operator %q", i, rule.TriggerOperator)
+		}
+		if len(rule.Triggers) == 0 {
+			return fmt.Errorf("form_field_rules[%d]: at least one trigger is required", i)
+		}
+		if len(rule.Actions) == 0 {
+			return fmt.Errorf("form_field_rules[%d]: at least one action is required", i)
+		}
+
+		for j, trigger := range rule.Triggers {
+			if !fieldIDs[trigger.ID] {
+				return fmt.Errorf("form_field_rules[%d][triggers][%d]: unknown field %q", i, j, trigger.ID)
+			}
+			switch trigger.Operator {
+			case TriggerIs, TriggerNot, TriggerMatch:
+				if len(trigger.Values) > 0 {
+					return fmt.Errorf("form_field_rules[%d][triggers][%d]: %s takes a single value", i, j, trigger.Operator)
+				}
+			case TriggerAny, TriggerNone:
+				if trigger.Value != "" || len(trigger.Values) == 0 {
+					return fmt.Errorf("form_field_rules[%d][triggers][%d]: %s requires values", i, j, trigger.Operator)
+				}
+			default:
+				return fmt.Errorf("form_field_rules[%d][triggers][%d]: unknown operator %q", i, j, trigger.Operator)
+			}
+		}
+
+		for j, action := range rule.Actions {
+			switch action.Type {
+			case ActionChangeFieldVisibility:
+				if action.GroupID != "" || !fieldIDs[action.FieldID] {
+					return fmt.Errorf("form_field_rules[%d][actions][%d]: unknown field %q", i, j, action.FieldID)
+				}
+			case ActionChangeGroupVisibility:
+				if action.FieldID != "" || !groupIDs[action.GroupID] {
+					return fmt.Errorf("form_field_rules[%d][actions][%d]: unknown group %q", i, j, action.GroupID)
+				}
+			default:
+				return fmt.Errorf("form_field_rules[%d][actions][%d]: unknown type %q", i, j, action.Type)
+			}
+		}
+	}
+	return nil
+}
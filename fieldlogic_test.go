@@ -0,0 +1,94 @@
+package hellosign
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func fieldLogicRequest() CreationRequest {
+	return CreationRequest{
+		FileURL: []string{"http://www.pdf995.com/samples/pdf.pdf"},
+		Signers: []Signer{{Email: "jane@example.com", Name: "Jane Doe"}},
+		FormFieldsPerDocument: [][]DocumentFormField{
+			{
+				{APIId: "opt_in", Type: FieldTypeCheckbox, Group: "choices"},
+				{APIId: "opt_out", Type: FieldTypeCheckbox, Group: "choices"},
+				{APIId: "reason", Type: FieldTypeText},
+			},
+		},
+		FormFieldGroups: []FieldGroup{
+			{ID: "choices", Label: "Pick one", Requirement: GroupRequireOne},
+		},
+		FormFieldRules: []FieldRule{
+			{
+				ID:              "show_reason",
+				TriggerOperator: "AND",
+				Triggers:        []RuleTrigger{{ID: "opt_out", Operator: TriggerIs, Value: TriggerValueChecked}},
+				Actions:         []RuleAction{{FieldID: "reason", Hidden: false, Type: ActionChangeFieldVisibility}},
+			},
+		},
+	}
+}
+
+func TestFieldGroupsAndRulesEncoded(t *testing.T) {
+	assert := assert.New(t)
+
+	object, form := encodeRequest(t, fieldLogicRequest())
+
+	assert.Equal(`[{"group_id":"choices","group_label":"Pick one","requirement":"require_1"}]`, form.Value["form_field_groups"][0])
+	assert.Equal(`[{"id":"show_reason","trigger_operator":"AND","triggers":[{"id":"opt_out","operator":"is","value":"1"}],"actions":[{"field_id":"reason","hidden":false,"type":"change-field-visibility"}]}]`, form.Value["form_field_rules"][0])
+
+	assert.Equal(`[{"group_id":"choices","group_label":"Pick one","requirement":"require_1"}]`, jsonValue(object["form_field_groups"]))
+	assert.Equal(`[{"actions":[{"field_id":"reason","hidden":false,"type":"change-field-visibility"}],"id":"show_reason","trigger_operator":"AND","triggers":[{"id":"opt_out","operator":"is","value":"1"}]}]`, jsonValue(object["form_field_rules"]))
+}
+
+func TestFieldGroupsAndRulesValidation(t *testing.T) {
+	assert := assert.New(t)
+	client := Client{}
+
+	cases := map[string]func(r *CreationRequest){
+		`form_field_groups[0]: unknown requirement "some"`: func(r *CreationRequest) {
+			r.FormFieldGroups[0].Requirement = "some"
+		},
+		`form_field_groups[1]: no form field belongs to group "other"`: func(r *CreationRequest) {
+			r.FormFieldGroups = append(r.FormFieldGroups, FieldGroup{ID: "other", Requirement: GroupRequireOne})
+		},
+		`form_field_rules[0][triggers][0]: unknown field "missing"`: func(r *CreationRequest) {
+			r.FormFieldRules[0].Triggers[0].ID = "missing"
+		},
+		`form_field_rules[0][triggers][0]: any requires values`: func(r *CreationRequest) {
+			r.FormFieldRules[0].Triggers[0].Operator = TriggerAny
+		},
+		`form_field_rules[0][actions][0]: unknown group "reason"`: func(r *CreationRequest) {
+			r.FormFieldRules[0].Actions[0].Type = ActionChangeGroupVisibility
+			r.FormFieldRules[0].Actions[0].GroupID = "reason"
+			r.FormFieldRules[0].Actions[0].FieldID = ""
+		},
+	}
+
+	for msg, mutate := range cases {
+		request := fieldLogicRequest()
+		mutate(&request)
+
+		_, _, err := client.marshalRequest(request)
+		if assert.NotNil(err, msg) {
+			assert.Equal("invalid request: "+msg, err.Error())
+		}
+	}
+}
+
+func TestFieldGroupsOmittedWhenEmpty(t *testing.T) {
+	assert := assert.New(t)
+
+	request := fieldLogicRequest()
+	request.FormFieldGroups = nil
+	request.FormFieldRules = nil
+
+	object, form := encodeRequest(t, request)
+
+	assert.NotContains(form.Value, "form_field_groups")
+	assert.NotContains(form.Value, "form_field_rules")
+	assert.NotContains(object, "form_field_groups")
+	assert.NotContains(object, "form_field_rules")
+}
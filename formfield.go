@@ -0,0 +1,182 @@
+package hellosign
+
+import (
+	"fmt"
+)
+
+// FormFieldType is the type of a DocumentFormField.
+type FormFieldType string
+
+// Form field types accepted in form_fields_per_document.
+const (
+	FieldTypeText          FormFieldType = "text"
+	FieldTypeTextMerge     FormFieldType = "text-merge"
+	FieldTypeCheckbox      FormFieldType = "checkbox"
+	FieldTypeCheckboxMerge FormFieldType = "checkbox-merge"
+	FieldTypeRadio         FormFieldType = "radio"
+	FieldTypeDropdown      FormFieldType = "dropdown"
+	FieldTypeSignature     FormFieldType = "signature"
+	FieldTypeInitials      FormFieldType = "initials"
+	FieldTypeDateSigned    FormFieldType = "date_signed"
+	FieldTypeHyperlink     FormFieldType = "hyperlink"
+)
+
+// Validation types for text fields.
+const (
+	ValidationNumbersOnly                  = "numbers_only"
+	ValidationLettersOnly                  = "letters_only"
+	ValidationPhoneNumber                  = "phone_number"
+	ValidationBankRoutingNumber            = "bank_routing_number"
+	ValidationBankAccountNumber            = "bank_account_number"
+	ValidationEmailAddress                 = "email_address"
+	ValidationZipCode                      = "zip_code"
+	ValidationSocialSecurityNumber         = "social_security_number"
+	ValidationEmployerIdentificationNumber = "employer_identification_number"
+	ValidationCustomRegex                  = "custom_regex"
+)
+
+// Date formats for date_signed fields.
+const (
+	DateFormatMonthDayYearSlash = "MM / DD / YYYY"
+	DateFormatMonthDayYearDash  = "MM - DD - YYYY"
+	DateFormatDayMonthYearSlash = "DD / MM / YYYY"
+	DateFormatDayMonthYearDash  = "DD - MM - YYYY"
+	DateFormatYearMonthDaySlash = "YYYY / MM / DD"
+	DateFormatYearMonthDayDash  = "YYYY - MM - DD"
+)
+
+const (
+	minFontSize = 7
+	maxFontSize = 49
+)
+
+var validationTypes = map[string]bool{
+	ValidationNumbersOnly:                  true,
+	ValidationLettersOnly:                  true,
+	ValidationPhoneNumber:                  true,
+	ValidationBankRoutingNumber:            true,
+	ValidationBankAccountNumber:            true,
+	ValidationEmailAddress:                 true,
+	ValidationZipCode:                      true,
+	ValidationSocialSecurityNumber:         true,
+	ValidationEmployerIdentificationNumber: true,
+	ValidationCustomRegex:                  true,
+}
+
+var dateFormats = map[string]bool{
+	DateFormatMonthDayYearSlash: true,
+	DateFormatMonthDayYearDash:  true,
+	DateFormatDayMonthYearSlash: true,
+	DateFormatDayMonthYearDash:  true,
+	DateFormatYearMonthDaySlash: true,
+	DateFormatYearMonthDayDash:  true,
+}
+
+// IsValid reports whether the type is one HelloSign accepts.
+func (t FormFieldType) IsValid() bool {
+	switch t {
+	case FieldTypeText, FieldTypeTextMerge, FieldTypeCheckbox, FieldTypeCheckboxMerge,
+		FieldTypeRadio, FieldTypeDropdown, FieldTypeSignature, FieldTypeInitials,
+		FieldTypeDateSigned, FieldTypeHyperlink:
+		return true
+	}
+	return false
+}
+
+func (t FormFieldType) isText() bool {
+	return t == FieldTypeText || t == FieldTypeTextMerge
+}
+
+// Validate checks that the field only uses attributes supported by its type.
+func (f DocumentFormField) Validate() error {
+	if f.APIId == "" {
+		return fmt.Errorf("api_id is required")
+	}
+	if !f.Type.IsValid() {
+		return fmt.Errorf("%s: unknown type %q", f.APIId, f.Type)
+	}
+	if f.Signer < 0 {
+		return fmt.Errorf("%s: signer must not be negative", f.APIId)
+	}
+	if f.Page < 0 {
+		return fmt.Errorf("%s: page must not be negative", f.APIId)
+	}
+	if f.FontSize != 0 && (f.FontSize < minFontSize || f.FontSize > maxFontSize) {
+		return fmt.Errorf("%s: font_size must be between %d and %d", f.APIId, minFontSize, maxFontSize)
+	}
+
+	if f.ValidationType != "" {
+		if !f.Type.isText() {
+			return fmt.Errorf("%s: validation_type is only supported on text fields", f.APIId)
+		}
+		if !validationTypes[f.ValidationType] {
+			return fmt.Errorf("%s: unknown validation_type %q", f.APIId, f.ValidationType)
+		}
+	}
+	if f.ValidationType == ValidationCustomRegex && f.ValidationCustomRegex == "" {
+		return fmt.Errorf("%s: validation_custom_regex is required for custom_regex validation", f.APIId)
+	}
+	if f.ValidationType != ValidationCustomRegex && (f.ValidationCustomRegex != "" || f.ValidationCustomFormat != "") {
+		return fmt.Errorf("%s: validation_custom_regex requires custom_regex validation", f.APIId)
+	}
+	if f.Placeholder != "" && !f.Type.isText() {
+		return fmt.Errorf("%s: placeholder is only supported on text fields", f.APIId)
+	}
+	if f.IsChecked != nil && f.Type != FieldTypeCheckbox && f.Type != FieldTypeRadio {
+		return fmt.Errorf("%s: is_checked is only supported on checkbox and radio fields", f.APIId)
+	}
+	if f.DateFormat != "" {
+		if f.Type != FieldTypeDateSigned {
+			return fmt.Errorf("%s: date_format is only supported on date_signed fields", f.APIId)
+		}
+		if !dateFormats[f.DateFormat] {
+			return fmt.Errorf("%s: unknown date_format %q", f.APIId, f.DateFormat)
+		}
+	}
+	if len(f.Options) > 0 && f.Type != FieldTypeDropdown {
+		return fmt.Errorf("%s: options are only supported on dropdown fields", f.APIId)
+	}
+	if f.ContentURL != "" && f.Type != FieldTypeHyperlink {
+		return fmt.Errorf("%s: content_url is only supported on hyperlink fields", f.APIId)
+	}
+
+	switch f.Type {
+	case FieldTypeRadio:
+		if f.Group == "" {
+			return fmt.Errorf("%s: radio fields must belong to a group", f.APIId)
+		}
+	case FieldTypeDropdown:
+		if len(f.Options) == 0 {
+			return fmt.Errorf("%s: dropdown fields require options", f.APIId)
+		}
+		if f.Content != "" && !containsString(f.Options, f.Content) {
+			return fmt.Errorf("%s: default content %q is not one of the options", f.APIId, f.Content)
+		}
+	case FieldTypeHyperlink:
+		if f.Content == "" || f.ContentURL == "" {
+			return fmt.Errorf("%s: hyperlink fields require content and content_url", f.APIId)
+		}
+	}
+
+	return nil
+}
+
+func validateFormFieldsPerDocument(documents [][]DocumentFormField) error {
+	for i, fields := range documents {
+		for j, field := range fields {
+			if err := field.Validate(); err != nil {
+				return fmt.Errorf("form_fields_per_document[%d][%d]: %v", i, j, err)
+			}
+		}
+	}
+	return nil
+}
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,78 @@
+package hellosign
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDocumentFormFieldValidate(t *testing.T) {
+	assert := assert.New(t)
+	checked := true
+
+	valid := []DocumentFormField{
+		{APIId: "name", Type: FieldTypeText, Placeholder: "Full name", ValidationType: ValidationLettersOnly, FontSize: 12},
+		{APIId: "zip", Type: FieldTypeText, ValidationType: ValidationCustomRegex, ValidationCustomRegex: "^[0-9]{5}$"},
+		{APIId: "agree", Type: FieldTypeCheckbox, IsChecked: &checked},
+		{APIId: "plan_a", Type: FieldTypeRadio, Group: "plan"},
+		{APIId: "state", Type: FieldTypeDropdown, Options: []string{"CA", "NY"}, Content: "CA"},
+		{APIId: "sig", Type: FieldTypeSignature, Page: 2},
+		{APIId: "date", Type: FieldTypeDateSigned, DateFormat: DateFormatYearMonthDayDash},
+		{APIId: "terms", Type: FieldTypeHyperlink, Content: "Terms", ContentURL: "https://example.com/terms"},
+	}
+	for _, f := range valid {
+		assert.Nil(f.Validate(), f.APIId)
+	}
+
+	invalid := map[string]DocumentFormField{
+		"api_id is required":                                                 {Type: FieldTypeText},
+		`x: unknown type "textarea"`:                                         {APIId: "x", Type: "textarea"},
+		"x: font_size must be between 7 and 49":                              {APIId: "x", Type: FieldTypeText, FontSize: 50},
+		"x: validation_type is only supported on text fields":                {APIId: "x", Type: FieldTypeCheckbox, ValidationType: ValidationZipCode},
+		"x: validation_custom_regex is required for custom_regex validation": {APIId: "x", Type: FieldTypeText, ValidationType: ValidationCustomRegex},
+		"x: placeholder is only supported on text fields":                    {APIId: "x", Type: FieldTypeSignature, Placeholder: "sign"},
+		"x: radio fields must belong to a group":                             {APIId: "x", Type: FieldTypeRadio},
+		"x: dropdown fields require options":                                 {APIId: "x", Type: FieldTypeDropdown},
+		`x: default content "TX" is not one of the options`:                  {APIId: "x", Type: FieldTypeDropdown, Options: []string{"CA"}, Content: "TX"},
+		"x: options are only supported on dropdown fields":                   {APIId: "x", Type: FieldTypeText, Options: []string{"CA"}},
+		"x: hyperlink fields require content and content_url":                {APIId: "x", Type: FieldTypeHyperlink, Content: "Terms"},
+		"x: date_format is only supported on date_signed fields":             {APIId: "x", Type: FieldTypeText, DateFormat: DateFormatDayMonthYearDash},
+	}
+	for msg, f := range invalid {
+		err := f.Validate()
+		if assert.NotNil(err, msg) {
+			assert.Equal(msg, err.Error())
+		}
+	}
+}
+
+func TestDocumentFormFieldJSON(t *testing.T) {
+	assert := assert.New(t)
+
+	data, err := json.Marshal(DocumentFormField{
+		APIId:    "state",
+		Name:     "State",
+		Type:     FieldTypeDropdown,
+		Options:  []string{"CA", "NY"},
+		Page:     1,
+		Required: true,
+	})
+
+	assert.Nil(err, "Should not return error")
+	assert.Equal(`{"api_id":"state","name":"State","type":"dropdown","x":0,"y":0,"width":0,"height":0,"required":true,"signer":0,"page":1,"options":["CA","NY"]}`, string(data))
+}
+
+func TestCreateEmbeddedSignatureRequestInvalidFormField(t *testing.T) {
+	client := Client{}
+
+	embReq := creationRequest()
+	embReq.FormFieldsPerDocument[1][0].Type = FieldTypeRadio
+
+	res, err := client.CreateEmbeddedSignatureRequest(embReq)
+
+	assert.Nil(t, res, "Should not return response")
+	assert.NotNil(t, err, "Should return error")
+
+	assert.Equal(t, "invalid request: form_fields_per_document[1][0]: api_id_2: radio fields must belong to a group", err.Error())
+}
@@ -5,4 +5,5 @@ go 1.14
 require (
 	github.com/dnaeon/go-vcr v1.2.0
 	github.com/stretchr/testify v1.7.1
+	gopkg.in/yaml.v3 v3.0.1
 )
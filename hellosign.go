@@ -2,17 +2,16 @@ package hellosign
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
-	"errors"
 	"fmt"
-	"io"
 	"io/ioutil"
-	"mime/multipart"
 	"net/http"
+	"net/url"
 	"os"
-	"reflect"
 	"strconv"
 	"strings"
+	"time"
 )
 
 const (
@@ -25,56 +24,110 @@ type Client struct {
 	ClientID   string
 	BaseURL    string
 	HTTPClient *http.Client
+	Hooks      Hooks   // Called around every API call.
+	Logger     Logger  // Optional. Logs method, path, status, duration and request ID of every call.
+	Debug      bool    // Also log request bodies, with files, PINs and the API key redacted.
+	Tracer     Tracer  // Optional. Starts a span for every call.
+	Metrics    Metrics // Optional. Records latency, count and errors of every call.
+
+	// Middleware wraps every API call, first entry outermost. Requests pass
+	// through it before the built-in hooks and logging, authentication and
+	// finally HTTPClient, so middleware such as Retry re-runs those on each attempt.
+	Middleware []Middleware
+
+	// DryRun, when set, answers every call locally instead of sending it. See DryRun.
+	DryRun *DryRun
+
+	// Policy, when set, forces test mode or refuses requests before they are sent. See Policy.
+	Policy *Policy
+
+	// Idempotency configures creates with an IdempotencyKey.
+	Idempotency IdempotencyPolicy
+
+	// FileCache, when set, keeps the files of completed requests. See FileCache.
+	FileCache FileCache
 }
 
 // CreationRequest contains the request parameters for create_embedded
+//
+// Sending from templates isn't supported, so Validate rejects TemplateID.
 type CreationRequest struct {
 	TestMode              bool                  `form_field:"test_mode"`
-	ClientID              string                `form_field:"client_id"`
+	ClientID              string                `form_field:"client_id,omitempty"`
 	TemplateID            []string              `form_field:"template_ids"`
 	FileURL               []string              `form_field:"file_url"`
-	File                  []string              `form_field:"file"`
-	Title                 string                `form_field:"title"`
-	Subject               string                `form_field:"subject"`
-	Message               string                `form_field:"message"`
-	SigningRedirectURL    string                `form_field:"signing_redirect_url"`
+	File                  []string              `form_field:"file,file"`
+	Title                 string                `form_field:"title,omitempty"`
+	Subject               string                `form_field:"subject,omitempty"`
+	Message               string                `form_field:"message,omitempty"`
+	SigningRedirectURL    string                `form_field:"signing_redirect_url,omitempty"`
 	Signers               []Signer              `form_field:"signers"`
 	Attachments           []Attachment          `form_field:"attachments"`
-	CustomFields          []CustomField         `form_field:"custom_fields"`
+	CustomFields          []CustomField         `form_field:"custom_fields,json,omitempty"`
 	CCEmailAddresses      []string              `form_field:"cc_email_addresses"`
 	UseTextTags           bool                  `form_field:"use_text_tags"`
 	HideTextTags          bool                  `form_field:"hide_text_tags"`
 	Metadata              map[string]string     `form_field:"metadata"`
 	AllowDecline          bool                  `form_field:"allow_decline"`
 	AllowReassign         bool                  `form_field:"allow_reassign"`
-	FormFieldsPerDocument [][]DocumentFormField `form_field:"form_fields_per_document"`
-	// FieldOptions map[string]string `form_field:"field_options"``
+	FormFieldsPerDocument [][]DocumentFormField `form_field:"form_fields_per_document,json,omitempty"`
+	FormFieldGroups       []FieldGroup          `form_field:"form_field_groups,json,omitempty"`
+	FormFieldRules        []FieldRule           `form_field:"form_field_rules,json,omitempty"`
+	SigningOptions        *SigningOptions       `form_field:"signing_options,json,omitempty"`
+	FieldOptions          *FieldOptions         `form_field:"field_options,json,omitempty"`
+	IdempotencyKey        string                `form_field:"-"` // Stamped into Metadata so a create with an uncertain outcome is not repeated. See IdempotencyPolicy.
 }
 
 type Signer struct {
-	Name  string `field:"name"`
-	Email string `field:"email_address"`
-	Order int    `field:"order"`
-	Pin   string `field:"pin"`
+	Name               string   `field:"name"`
+	Email              string   `field:"email_address"`
+	Order              int      `field:"order,omitempty"`
+	Pin                string   `field:"pin,omitempty"`
+	SMSPhoneNumber     string   `field:"sms_phone_number,omitempty"`      // Mobile number used for SMS authentication or delivery.
+	SMSPhoneNumberType string   `field:"sms_phone_number_type,omitempty"` // See the SMSPhoneNumberType* constants.
+	Group              string   `field:"-"`                               // Name of the signer group. Only used together with GroupMembers.
+	GroupMembers       []Signer `field:"-"`                               // People in the group; any one of them may sign. Encoded as signers[i][j][...], or as a "signers" array in JSON bodies.
 }
 
 type DocumentFormField struct {
-	APIId    string `json:"api_id"`
-	Name     string `json:"name"`
-	Type     string `json:"type"`
-	X        int    `json:"x"`
-	Y        int    `json:"y"`
-	Width    int    `json:"width"`
-	Height   int    `json:"height"`
-	Required bool   `json:"required"`
-	Signer   int    `json:"signer"`
+	APIId                  string        `json:"api_id"`                                         // A unique id for the form field.
+	Name                   string        `json:"name"`                                           // The name of the form field.
+	Type                   FormFieldType `json:"type"`                                           // The type of the form field. See FormFieldType.
+	X                      int           `json:"x"`                                              // Location coordinates of the field in pixels.
+	Y                      int           `json:"y"`                                              // Location coordinates of the field in pixels.
+	Width                  int           `json:"width"`                                          // Width of the field in pixels.
+	Height                 int           `json:"height"`                                         // Height of the field in pixels.
+	Required               bool          `json:"required"`                                       // Whether the field is required.
+	Signer                 int           `json:"signer"`                                         // Signer index identified by the offset in the signers parameter (0-based indexing).
+	Page                   int           `json:"page,omitempty"`                                 // Page number of the document (1-based) to place the field on.
+	ValidationType         string        `json:"validation_type,omitempty"`                      // Text fields only. See the Validation* constants.
+	ValidationCustomRegex  string        `json:"validation_custom_regex,omitempty"`              // Text fields only. The regex used when ValidationType is custom_regex.
+	ValidationCustomFormat string        `json:"validation_custom_regex_format_label,omitempty"` // Text fields only. Label shown to the signer when the custom regex does not match.
+	Placeholder            string        `json:"placeholder,omitempty"`                          // Text fields only. Placeholder shown before the signer fills in the field.
+	Options                []string      `json:"options,omitempty"`                              // Dropdown fields only. The choices presented to the signer.
+	Content                string        `json:"content,omitempty"`                              // Default dropdown option or the text of a hyperlink field.
+	ContentURL             string        `json:"content_url,omitempty"`                          // Hyperlink fields only. The link target.
+	FontSize               int           `json:"font_size,omitempty"`                            // Initial font size of the field contents, between 7 and 49.
+	FontFamily             string        `json:"font_family,omitempty"`                          // Font family of the field contents.
+	Group                  string        `json:"group,omitempty"`                                // Group the field belongs to. Required for radio fields.
+	IsChecked              *bool         `json:"is_checked,omitempty"`                           // Checkbox and radio fields only. Whether the field is initially checked.
+	DateFormat             string        `json:"date_format,omitempty"`                          // Date signed fields only. See the DateFormat* constants.
 }
 
 type Attachment struct {
-	Name         string `field:"name"`
-	Instructions string `field:"instructions"`
+	Name         string `field:"name,omitempty"`
+	Instructions string `field:"instructions,omitempty"`
 	SignerIndex  int    `field:"signer_index"`
-	Required     bool   `field:"required"`
+	Required     bool   `field:"required,omitempty"`
+}
+
+type updateRequest struct {
+	SignatureID string `form_field:"signature_id"`
+	Email       string `form_field:"email_address"`
+}
+
+type remindRequest struct {
+	Email string `form_field:"email_address"`
 }
 
 type SignatureRequestResponse struct {
@@ -90,7 +143,7 @@ type SignatureRequest struct {
 	Subject               string                   `json:"subject"`                 // The subject in the email that was initially sent to the signers.
 	Message               string                   `json:"message"`                 // The custom message in the email that was initially sent to the signers.
 	Metadata              map[string]interface{}   `json:"metadata"`                // The metadata attached to the signature request.
-	CreatedAt             int                      `json:"created_at"`              // Time the signature request was created.
+	CreatedAt             Timestamp                `json:"created_at"`              // Time the signature request was created.
 	IsComplete            bool                     `json:"is_complete"`             // Whether or not the SignatureRequest has been fully executed by all signers.
 	IsDeclined            bool                     `json:"is_declined"`             // Whether or not the SignatureRequest has been declined by a signer.
 	HasError              bool                     `json:"has_error"`               // Whether or not an error occurred (either during the creation of the SignatureRequest or during one of the signings).
@@ -106,37 +159,37 @@ type SignatureRequest struct {
 }
 
 type CustomField struct {
-	Name     string      `json:"name"`     // The name of the Custom Field.
-	Type     string      `json:"type"`     // The type of this Custom Field. Only 'text' and 'checkbox' are currently supported.
-	Value    interface{} `json:"value"`    // A text string for text fields or true/false for checkbox fields
-	Required bool        `json:"required"` // A boolean value denoting if this field is required.
-	ApiID    string      `json:"api_id"`   // The unique ID for this field.
-	Editor   *string     `json:"editor"`   // The name of the Role that is able to edit this field.
+	Name     string      `json:"name"`             // The name of the Custom Field.
+	Type     string      `json:"type,omitempty"`   // The type of this Custom Field. Only 'text' and 'checkbox' are currently supported.
+	Value    interface{} `json:"value"`            // A text string for text fields or true/false for checkbox fields
+	Required bool        `json:"required"`         // A boolean value denoting if this field is required.
+	ApiID    string      `json:"api_id,omitempty"` // The unique ID for this field.
+	Editor   *string     `json:"editor,omitempty"` // The name of the Role that is able to edit this field.
 }
 
 type ResponseData struct {
-	ApiID       string `json:"api_id"`       // The unique ID for this field.
-	SignatureID string `json:"signature_id"` // The ID of the signature to which this response is linked.
-	Name        string `json:"name"`         // The name of the form field.
-	Value       string `json:"value"`        // The value of the form field.
-	Required    bool   `json:"required"`     // A boolean value denoting if this field is required.
-	Type        string `json:"type"`         // The type of this form field. See field types
+	ApiID       string     `json:"api_id"`       // The unique ID for this field.
+	SignatureID string     `json:"signature_id"` // The ID of the signature to which this response is linked.
+	Name        string     `json:"name"`         // The name of the form field.
+	Value       FieldValue `json:"value"`        // The value of the form field. A string, bool or null depending on the field type.
+	Required    bool       `json:"required"`     // A boolean value denoting if this field is required.
+	Type        string     `json:"type"`         // The type of this form field. See field types
 }
 
 type Signature struct {
-	SignatureID        string  `json:"signature_id"`         // Signature identifier.
-	SignerEmailAddress string  `json:"signer_email_address"` // The email address of the signer.
-	SignerName         string  `json:"signer_name"`          // The name of the signer.
-	Order              int     `json:"order"`                // If signer order is assigned this is the 0-based index for this signer.
-	StatusCode         string  `json:"status_code"`          // The current status of the signature. eg: awaiting_signature, signed, declined
-	DeclineReason      string  `json:"decline_reason"`       // The reason provided by the signer for declining the request.
-	SignedAt           int     `json:"signed_at"`            // Time that the document was signed or null.
-	LastViewedAt       int     `json:"last_viewed_at"`       // The time that the document was last viewed by this signer or null.
-	LastRemindedAt     int     `json:"last_reminded_at"`     // The time the last reminder email was sent to the signer or null.
-	HasPin             bool    `json:"has_pin"`              // Boolean to indicate whether this signature requires a PIN to access.
-	ReassignedBy       string  `json:"reassigned_by"`        // Email address of original signer who reassigned to this signer.
-	ReassignmentReason string  `json:"reassignment_reason"`  // Reason provided by original signer who reassigned to this signer.
-	Error              *string `json:"error"`                // Error message pertaining to this signer, or null.
+	SignatureID        string          `json:"signature_id"`         // Signature identifier.
+	SignerEmailAddress string          `json:"signer_email_address"` // The email address of the signer.
+	SignerName         string          `json:"signer_name"`          // The name of the signer.
+	Order              int             `json:"order"`                // If signer order is assigned this is the 0-based index for this signer.
+	StatusCode         SignatureStatus `json:"status_code"`          // The current status of the signature. eg: awaiting_signature, signed, declined
+	DeclineReason      string          `json:"decline_reason"`       // The reason provided by the signer for declining the request.
+	SignedAt           *Timestamp      `json:"signed_at"`            // Time that the document was signed or null.
+	LastViewedAt       *Timestamp      `json:"last_viewed_at"`       // The time that the document was last viewed by this signer or null.
+	LastRemindedAt     *Timestamp      `json:"last_reminded_at"`     // The time the last reminder email was sent to the signer or null.
+	HasPin             bool            `json:"has_pin"`              // Boolean to indicate whether this signature requires a PIN to access.
+	ReassignedBy       string          `json:"reassigned_by"`        // Email address of original signer who reassigned to this signer.
+	ReassignmentReason string          `json:"reassignment_reason"`  // Reason provided by original signer who reassigned to this signer.
+	Error              *string         `json:"error"`                // Error message pertaining to this signer, or null.
 }
 
 type Warning struct {
@@ -156,6 +209,31 @@ type ListInfo struct {
 	PageSize   int `json:"page_size"`   // Objects returned per page
 }
 
+// ListOptions filters and pages ListSignatureRequestsWithOptions. Zero values are omitted.
+type ListOptions struct {
+	AccountID string // Which account to return requests for. Use "all" for every team member.
+	Page      int    // Which page number of the list to return. Defaults to 1.
+	PageSize  int    // Number of objects to be returned per page, between 1 and 100. Defaults to 20.
+	Query     string // Search terms and/or fields to filter by, e.g. "title:Offer" or "jane@example.com".
+}
+
+func (o ListOptions) values() url.Values {
+	values := url.Values{}
+	if o.AccountID != "" {
+		values.Set("account_id", o.AccountID)
+	}
+	if o.Page > 0 {
+		values.Set("page", strconv.Itoa(o.Page))
+	}
+	if o.PageSize > 0 {
+		values.Set("page_size", strconv.Itoa(o.PageSize))
+	}
+	if o.Query != "" {
+		values.Set("query", o.Query)
+	}
+	return values
+}
+
 type ErrorResponse struct {
 	Error    *Error    `json:"error"`
 	Warnings []Warning `json:"warnings"`
@@ -166,13 +244,27 @@ type Error struct {
 	Name    string `json:"error_name"`
 }
 
+// APIError is returned when HelloSign responds with a 4xx or 5xx status.
+type APIError struct {
+	StatusCode int    // HTTP status of the response.
+	Name       string // HelloSign error_name, e.g. "not_found". Empty when the response had none.
+	Message    string // HelloSign error_msg, or the joined warnings.
+}
+
+func (e *APIError) Error() string {
+	if e.Name == "" {
+		return e.Message
+	}
+	return fmt.Sprintf("%s: %s", e.Name, e.Message)
+}
+
 type EmbeddedResponse struct {
 	Embedded *SignURLResponse `json:"embedded"`
 }
 
 type SignURLResponse struct {
-	SignURL   string `json:"sign_url"`   // URL of the signature page to display in the embedded iFrame.
-	ExpiresAt int    `json:"expires_at"` // When the link expires.
+	SignURL   string    `json:"sign_url"`   // URL of the signature page to display in the embedded iFrame.
+	ExpiresAt Timestamp `json:"expires_at"` // When the link expires.
 }
 
 func (m *Client) WithHTTPClient(httpClient *http.Client) *Client {
@@ -182,13 +274,24 @@ func (m *Client) WithHTTPClient(httpClient *http.Client) *Client {
 }
 
 // CreateEmbeddedSignatureRequest creates a new embedded signature
-func (m *Client) createSignatureRequest(path string, request CreationRequest) (*SignatureRequest, error) {
-	params, writer, err := m.marshalMultipartRequest(request)
+func (m *Client) createSignatureRequest(ctx context.Context, path string, request CreationRequest) (*SignatureRequest, error) {
+	request, err := m.Policy.apply(request)
 	if err != nil {
 		return nil, err
 	}
+	if request.IdempotencyKey != "" {
+		return m.createIdempotent(ctx, path, request)
+	}
+	return m.create(ctx, path, request)
+}
 
-	response, err := m.post(path, params, *writer)
+func (m *Client) create(ctx context.Context, path string, request CreationRequest) (*SignatureRequest, error) {
+	params, contentType, err := m.marshalRequest(request)
+	if err != nil {
+		return nil, err
+	}
+
+	response, err := m.requestContext(ctx, "POST", path, params, contentType)
 	if err != nil {
 		return nil, err
 	}
@@ -198,18 +301,34 @@ func (m *Client) createSignatureRequest(path string, request CreationRequest) (*
 
 // CreateSignatureRequest creates non-embedded signature request.
 func (m *Client) CreateSignatureRequest(request CreationRequest) (*SignatureRequest, error) {
-	return m.createSignatureRequest("signature_request/send", request)
+	return m.CreateSignatureRequestContext(context.Background(), request)
+}
+
+// CreateSignatureRequestContext - Creates a non-embedded signature request, giving
+// up when ctx is done, also while retrying an idempotent create.
+func (m *Client) CreateSignatureRequestContext(ctx context.Context, request CreationRequest) (*SignatureRequest, error) {
+	return m.createSignatureRequest(ctx, "signature_request/send", request)
 }
 
 // CreateEmbeddedSignatureRequest creates a new embedded signature
 func (m *Client) CreateEmbeddedSignatureRequest(request CreationRequest) (*SignatureRequest, error) {
-	return m.createSignatureRequest("signature_request/create_embedded", request)
+	return m.CreateEmbeddedSignatureRequestContext(context.Background(), request)
+}
+
+// CreateEmbeddedSignatureRequestContext - Creates an embedded signature request,
+// giving up when ctx is done, also while retrying an idempotent create.
+func (m *Client) CreateEmbeddedSignatureRequestContext(ctx context.Context, request CreationRequest) (*SignatureRequest, error) {
+	return m.createSignatureRequest(ctx, "signature_request/create_embedded", request)
 }
 
 // GetSignatureRequest - Gets a SignatureRequest that includes the current status for each signer.
 func (m *Client) GetSignatureRequest(signatureRequestID string) (*SignatureRequest, error) {
+	return m.getSignatureRequest(context.Background(), signatureRequestID)
+}
+
+func (m *Client) getSignatureRequest(ctx context.Context, signatureRequestID string) (*SignatureRequest, error) {
 	path := fmt.Sprintf("signature_request/%s", signatureRequestID)
-	response, err := m.get(path)
+	response, err := m.requestContext(ctx, "GET", path, &bytes.Buffer{}, "")
 	if err != nil {
 		return nil, err
 	}
@@ -235,6 +354,9 @@ func (m *Client) GetEmbeddedSignURL(signatureRequestID string) (*SignURLResponse
 
 func (m *Client) SaveFile(signatureRequestID, fileType, destFilePath string) (os.FileInfo, error) {
 	byteArray, err := m.GetFiles(signatureRequestID, fileType)
+	if err != nil {
+		return nil, err
+	}
 
 	out, err := os.Create(destFilePath)
 	if err != nil {
@@ -258,25 +380,40 @@ func (m *Client) GetPDF(signatureRequestID string) ([]byte, error) {
 // GetFiles - Obtain a copy of the current documents specified by the signature_request_id parameter.
 // signatureRequestID - The id of the SignatureRequest to retrieve.
 // fileType - Set to "pdf" for a single merged document or "zip" for a collection of individual documents.
+// With a FileCache, the files of completed requests are served from it.
 func (m *Client) GetFiles(signatureRequestID, fileType string) ([]byte, error) {
-	path := fmt.Sprintf("signature_request/files/%s", signatureRequestID)
-
-	var params bytes.Buffer
-	writer := multipart.NewWriter(&params)
+	return m.files(context.Background(), signatureRequestID, fileType)
+}
 
-	signatureIDField, err := writer.CreateFormField("file_type")
-	if err != nil {
-		return nil, err
+// files downloads the files, checking the request status first only when a
+// FileCache is configured.
+func (m *Client) files(ctx context.Context, signatureRequestID, fileType string) ([]byte, error) {
+	if m.FileCache == nil {
+		return m.getFiles(ctx, signatureRequestID, fileType)
+	}
+	if data, ok := m.FileCache.Get(signatureRequestID, fileType); ok {
+		return data, nil
 	}
-	signatureIDField.Write([]byte(fileType))
 
-	emailField, err := writer.CreateFormField("get_url")
+	// Check before downloading: checking after could cache files downloaded
+	// just before the request completed as final.
+	request, err := m.getSignatureRequest(ctx, signatureRequestID)
 	if err != nil {
 		return nil, err
 	}
-	emailField.Write([]byte("false"))
+	data, err := m.getFiles(ctx, signatureRequestID, fileType)
+	if err == nil && request.IsComplete {
+		m.FileCache.Put(signatureRequestID, fileType, data)
+	}
+	return data, err
+}
 
-	response, err := m.request("GET", path, &params, *writer)
+func (m *Client) getFiles(ctx context.Context, signatureRequestID, fileType string) ([]byte, error) {
+	// HelloSign reads file_type from the query string; a GET body is ignored.
+	query := url.Values{"file_type": {fileType}}
+	path := fmt.Sprintf("signature_request/files/%s?%s", signatureRequestID, query.Encode())
+
+	response, err := m.requestContext(ctx, "GET", path, &bytes.Buffer{}, "")
 	if err != nil {
 		return nil, err
 	}
@@ -293,8 +430,20 @@ func (m *Client) GetFiles(signatureRequestID, fileType string) ([]byte, error) {
 
 // ListSignatureRequests - Lists the SignatureRequests (both inbound and outbound) that you have access to.
 func (m *Client) ListSignatureRequests() (*ListResponse, error) {
-	path := fmt.Sprintf("signature_request/list")
-	response, err := m.get(path)
+	return m.ListSignatureRequestsWithOptions(ListOptions{})
+}
+
+// ListSignatureRequestsWithOptions - Lists the SignatureRequests matching opts.
+func (m *Client) ListSignatureRequestsWithOptions(opts ListOptions) (*ListResponse, error) {
+	return m.listSignatureRequests(context.Background(), opts)
+}
+
+func (m *Client) listSignatureRequests(ctx context.Context, opts ListOptions) (*ListResponse, error) {
+	path := "signature_request/list"
+	if query := opts.values().Encode(); query != "" {
+		path += "?" + query
+	}
+	response, err := m.requestContext(ctx, "GET", path, &bytes.Buffer{}, "")
 	if err != nil {
 		return nil, err
 	}
@@ -312,24 +461,35 @@ func (m *Client) ListSignatureRequests() (*ListResponse, error) {
 
 // UpdateSignatureRequest - Update an email address on a signature request.
 func (m *Client) UpdateSignatureRequest(signatureRequestID string, signatureID string, email string) (*SignatureRequest, error) {
+	if err := m.Policy.checkEmailUpdate(email); err != nil {
+		return nil, err
+	}
+
 	path := fmt.Sprintf("signature_request/update/%s", signatureRequestID)
 
-	var params bytes.Buffer
-	writer := multipart.NewWriter(&params)
+	params, contentType, err := marshalBody(updateRequest{SignatureID: signatureID, Email: email})
+	if err != nil {
+		return nil, err
+	}
 
-	signatureIDField, err := writer.CreateFormField("signature_id")
+	response, err := m.post(path, params, contentType)
 	if err != nil {
 		return nil, err
 	}
-	signatureIDField.Write([]byte(signatureID))
 
-	emailField, err := writer.CreateFormField("email_address")
+	return m.sendSignatureRequest(response)
+}
+
+// RemindSignatureRequest - Sends an email to the signer reminding them to sign the signature request.
+func (m *Client) RemindSignatureRequest(signatureRequestID string, email string) (*SignatureRequest, error) {
+	path := fmt.Sprintf("signature_request/remind/%s", signatureRequestID)
+
+	params, contentType, err := marshalBody(remindRequest{Email: email})
 	if err != nil {
 		return nil, err
 	}
-	emailField.Write([]byte(email))
 
-	response, err := m.post(path, &params, *writer)
+	response, err := m.post(path, params, contentType)
 	if err != nil {
 		return nil, err
 	}
@@ -351,6 +511,10 @@ func (m *Client) CancelSignatureRequest(signatureRequestID string) (*http.Respon
 
 // SendSignatureRequest - Creates and sends a new SignatureRequest with the submitted documents.
 func (m *Client) SendSignatureRequest(request SignatureRequest) (*http.Response, error) {
+	if err := m.Policy.checkSend(request); err != nil {
+		return nil, err
+	}
+
 	path := fmt.Sprintf("signature_request/send")
 
 	response, err := m.nakedPost(path)
@@ -363,218 +527,84 @@ func (m *Client) SendSignatureRequest(request SignatureRequest) (*http.Response,
 
 // Private Methods
 
-func (m *Client) marshalMultipartRequest(
-	request CreationRequest) (*bytes.Buffer, *multipart.Writer, error) {
-
-	var b bytes.Buffer
-	w := multipart.NewWriter(&b)
-
-	structType := reflect.TypeOf(request)
-	val := reflect.ValueOf(request)
-
-	for i := 0; i < val.NumField(); i++ {
-
-		valueField := val.Field(i)
-		f := valueField.Interface()
-		val := reflect.ValueOf(f)
-		field := structType.Field(i)
-		fieldTag := field.Tag.Get("form_field")
-
-		switch val.Kind() {
-		case reflect.Map:
-			for k, v := range request.Metadata {
-				formField, err := w.CreateFormField(fmt.Sprintf("metadata[%v]", k))
-				if err != nil {
-					return nil, nil, err
-				}
-				formField.Write([]byte(v))
-			}
-		case reflect.Slice:
-			switch fieldTag {
-			case "signers":
-				for i, signer := range request.Signers {
-					email, err := w.CreateFormField(fmt.Sprintf("signers[%v][email_address]", i))
-					if err != nil {
-						return nil, nil, err
-					}
-					email.Write([]byte(signer.Email))
-
-					name, err := w.CreateFormField(fmt.Sprintf("signers[%v][name]", i))
-					if err != nil {
-						return nil, nil, err
-					}
-					name.Write([]byte(signer.Name))
-
-					if signer.Order != 0 {
-						order, err := w.CreateFormField(fmt.Sprintf("signers[%v][order]", i))
-						if err != nil {
-							return nil, nil, err
-						}
-						order.Write([]byte(strconv.Itoa(signer.Order)))
-					}
-
-					if signer.Pin != "" {
-						pin, err := w.CreateFormField(fmt.Sprintf("signers[%v][pin]", i))
-						if err != nil {
-							return nil, nil, err
-						}
-						pin.Write([]byte(signer.Pin))
-					}
-				}
-			case "attachments":
-				for i, attachment := range request.Attachments {
-
-					if attachment.Name != "" {
-						name, err := w.CreateFormField(fmt.Sprintf("attachments[%v][name]", i))
-						if err != nil {
-							return nil, nil, err
-						}
-						name.Write([]byte(attachment.Name))
-					}
-					if attachment.Instructions != "" {
-						text, err := w.CreateFormField(fmt.Sprintf("attachments[%v][instructions]", i))
-						if err != nil {
-							return nil, nil, err
-						}
-						text.Write([]byte(attachment.Instructions))
-					}
-
-					order, err := w.CreateFormField(fmt.Sprintf("attachments[%v][signer_index]", i))
-					if err != nil {
-						return nil, nil, err
-					}
-					order.Write([]byte(strconv.Itoa(attachment.SignerIndex)))
-
-					if attachment.Required {
-						required, err := w.CreateFormField(fmt.Sprintf("attachments[%v][required]", i))
-						if err != nil {
-							return nil, nil, err
-						}
-						required.Write([]byte(strconv.Itoa(1)))
-					}
-				}
-
-			case "cc_email_addresses":
-				for k, v := range request.CCEmailAddresses {
-					formField, err := w.CreateFormField(fmt.Sprintf("cc_email_addresses[%v]", k))
-					if err != nil {
-						return nil, nil, err
-					}
-					formField.Write([]byte(v))
-				}
-			case "form_fields_per_document":
-				if len(request.FormFieldsPerDocument) > 0 {
-					formField, err := w.CreateFormField(fieldTag)
-					if err != nil {
-						return nil, nil, err
-					}
-					ffpdJSON, err := json.Marshal(request.FormFieldsPerDocument)
-					if err != nil {
-						return nil, nil, err
-					}
-					formField.Write([]byte(ffpdJSON))
-				}
-			case "file":
-				for i, path := range request.File {
-					file, _ := os.Open(path)
-
-					formField, err := w.CreateFormFile(fmt.Sprintf("file[%v]", i), file.Name())
-					if err != nil {
-						return nil, nil, err
-					}
-					_, err = io.Copy(formField, file)
-				}
-			case "file_url":
-				for i, fileURL := range request.FileURL {
-					formField, err := w.CreateFormField(fmt.Sprintf("file_url[%v]", i))
-					if err != nil {
-						return nil, nil, err
-					}
-					formField.Write([]byte(fileURL))
-				}
-			}
-		case reflect.Bool:
-			formField, err := w.CreateFormField(fieldTag)
-			if err != nil {
-				return nil, nil, err
-			}
-			formField.Write([]byte(m.boolToIntString(val.Bool())))
-		default:
-			if val.String() != "" {
-				formField, err := w.CreateFormField(fieldTag)
-				if err != nil {
-					return nil, nil, err
-				}
-				formField.Write([]byte(val.String()))
-			}
-		}
+// marshalRequest encodes the request as JSON, or as multipart/form-data when it uploads files.
+func (m *Client) marshalRequest(request CreationRequest) (*bytes.Buffer, string, error) {
+	if err := request.Validate(); err != nil {
+		return nil, "", err
 	}
-
-	w.Close()
-	return &b, w, nil
+	return marshalBody(request)
 }
 
 func (m *Client) get(path string) (*http.Response, error) {
-	endpoint := fmt.Sprintf("%s%s", m.getEndpoint(), path)
+	return m.request("GET", path, &bytes.Buffer{}, "")
+}
 
-	var b bytes.Buffer
-	request, _ := http.NewRequest("GET", endpoint, &b)
-	request.SetBasicAuth(m.APIKey, "")
+func (m *Client) post(path string, params *bytes.Buffer, contentType string) (*http.Response, error) {
+	return m.request("POST", path, params, contentType)
+}
 
-	response, err := m.getHTTPClient().Do(request)
-	if err != nil {
-		return nil, err
-	}
+func (m *Client) request(method string, path string, params *bytes.Buffer, contentType string) (*http.Response, error) {
+	return m.requestContext(context.Background(), method, path, params, contentType)
+}
 
-	return response, err
+func (m *Client) requestContext(ctx context.Context, method string, path string, params *bytes.Buffer, contentType string) (*http.Response, error) {
+	return m.send(m.newRequest(method, path, params, contentType).WithContext(ctx), true)
 }
 
-func (m *Client) post(path string, params *bytes.Buffer, w multipart.Writer) (*http.Response, error) {
-	return m.request("POST", path, params, w)
+func (m *Client) nakedPost(path string) (*http.Response, error) {
+	return m.send(m.newRequest("POST", path, &bytes.Buffer{}, ""), false)
 }
 
-func (m *Client) request(method string, path string, params *bytes.Buffer, w multipart.Writer) (*http.Response, error) {
+func (m *Client) newRequest(method string, path string, params *bytes.Buffer, contentType string) *http.Request {
 	endpoint := fmt.Sprintf("%s%s", m.getEndpoint(), path)
 	request, _ := http.NewRequest(method, endpoint, params)
-	request.Header.Add("Content-Type", w.FormDataContentType())
-	request.SetBasicAuth(m.APIKey, "")
-
-	response, err := m.getHTTPClient().Do(request)
-	if err != nil {
-		return nil, err
+	if contentType != "" {
+		request.Header.Add("Content-Type", contentType)
 	}
+	return request
+}
 
-	if response.StatusCode >= 400 {
-		msg := fmt.Sprintf("hellosign request failed with status %d", response.StatusCode)
-		e := &ErrorResponse{}
-		json.NewDecoder(response.Body).Decode(e)
-		if e.Error != nil {
-			msg = fmt.Sprintf("%s: %s", e.Error.Name, e.Error.Message)
-		} else {
-			messages := []string{}
-			for _, w := range e.Warnings {
-				messages = append(messages, fmt.Sprintf("%s: %s", w.Name, w.Message))
-			}
-			msg = strings.Join(messages, ", ")
-		}
+// send sends the request through the middleware chain inside a span, turning error responses into errors when check is set.
+func (m *Client) send(request *http.Request, check bool) (*http.Response, error) {
+	call := m.newCall(request)
+	ctx, span := m.startSpan(request.Context(), call)
+	request = request.WithContext(ctx)
 
-		return response, errors.New(msg)
+	start := time.Now()
+	response, err := m.transport().RoundTrip(request)
+	if err == nil && check {
+		err = responseError(response)
+	}
+	if err != nil {
+		m.failed(request, response, time.Since(start), err)
 	}
 
+	m.finishCall(ctx, span, call, response, time.Since(start), err)
 	return response, err
 }
 
-func (m *Client) nakedPost(path string) (*http.Response, error) {
-	endpoint := fmt.Sprintf("%s%s", m.getEndpoint(), path)
-	var b bytes.Buffer
-	request, _ := http.NewRequest("POST", endpoint, &b)
-	request.SetBasicAuth(m.APIKey, "")
+// responseError decodes the error returned with a 4xx or 5xx status into an *APIError.
+func responseError(response *http.Response) error {
+	if response.StatusCode < 400 {
+		return nil
+	}
 
-	response, err := m.getHTTPClient().Do(request)
-	if err != nil {
-		return nil, err
+	apiErr := &APIError{
+		StatusCode: response.StatusCode,
+		Message:    fmt.Sprintf("hellosign request failed with status %d", response.StatusCode),
 	}
-	return response, err
+	e := &ErrorResponse{}
+	json.NewDecoder(response.Body).Decode(e)
+	if e.Error != nil {
+		apiErr.Name, apiErr.Message = e.Error.Name, e.Error.Message
+	} else if len(e.Warnings) > 0 {
+		messages := []string{}
+		for _, w := range e.Warnings {
+			messages = append(messages, fmt.Sprintf("%s: %s", w.Name, w.Message))
+		}
+		apiErr.Message = strings.Join(messages, ", ")
+	}
+	return apiErr
 }
 
 func (m *Client) sendSignatureRequest(response *http.Response) (*SignatureRequest, error) {
@@ -608,10 +638,3 @@ func (m *Client) getHTTPClient() *http.Client {
 	}
 	return httpClient
 }
-
-func (m *Client) boolToIntString(value bool) string {
-	if value == true {
-		return "1"
-	}
-	return "0"
-}
@@ -6,8 +6,8 @@ import (
 	"os"
 	"testing"
 
-	"github.com/dnaeon/go-vcr/cassette"
 	"github.com/dnaeon/go-vcr/recorder"
+	"github.com/jheth/hellosign-go-sdk/hellosigntest/recording"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -66,7 +66,9 @@ func TestCreateEmbeddedSignatureRequestMissingSigners(t *testing.T) {
 	assert.Nil(t, res, "Should not return response")
 	assert.NotNil(t, err, "Should return error")
 
-	assert.Equal(t, err.Error(), "bad_request: Must specify a name for each signer")
+	// Caught by CreationRequest.Validate before the request is sent.
+	assert.IsType(t, &ValidationError{}, err)
+	assert.Equal(t, "invalid request: signers: at least one signer is required", err.Error())
 }
 func TestCreateEmbeddedSignatureRequestWarnings(t *testing.T) {
 	// Start our recorder
@@ -165,7 +167,8 @@ func TestGetEmbeddedSignURL(t *testing.T) {
 	assert.Nil(t, err, "Should not return error")
 
 	assert.Contains(t, res.SignURL, "embeddedSign?signature_id=deaf86bfb33764d9a215a07cc060122d&token=")
-	assert.Equal(t, 1505259198, res.ExpiresAt)
+	assert.Equal(t, int64(1505259198), res.ExpiresAt.Unix())
+	assert.True(t, res.Expired())
 }
 
 func TestSaveFile(t *testing.T) {
@@ -265,17 +268,12 @@ func TestClient_WithHTTPClient(t *testing.T) {
 // Private Functions
 
 func fixture(path string) *recorder.Recorder {
-	vcr, err := recorder.New(path)
+	// Records when HELLOSIGN_API_KEY is set and scrubs secrets before saving.
+	vcr, err := recording.NewRecorder(path)
 	if err != nil {
 		log.Fatal(err)
 	}
 
-	// Add a filter which removes Authorization headers from all requests:
-	vcr.AddFilter(func(i *cassette.Interaction) error {
-		delete(i.Request.Headers, "Authorization")
-		return nil
-	})
-
 	return vcr
 }
 
@@ -0,0 +1,83 @@
+package hellosigntest
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	hellosign "github.com/jheth/hellosign-go-sdk"
+)
+
+// Events returns every event generated so far, oldest first, whether or not
+// it was delivered.
+func (s *Server) Events() []hellosign.Event {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return append([]hellosign.Event{}, s.events...)
+}
+
+// DeliverEvent posts an event of the given type for the signature request to callbackURL.
+func (s *Server) DeliverEvent(callbackURL, eventType, signatureRequestID string) error {
+	request, ok := s.SignatureRequest(signatureRequestID)
+	if !ok {
+		return fmt.Errorf("hellosigntest: unknown signature request %s", signatureRequestID)
+	}
+	return s.post(callbackURL, s.newEvent(eventType, request, ""))
+}
+
+func (s *Server) newEvent(eventType string, request *hellosign.SignatureRequest, signatureID string) hellosign.Event {
+	eventTime := strconv.FormatInt(s.Now().Unix(), 10)
+	metadata := map[string]interface{}{
+		"related_signature_id":    nil,
+		"reported_for_account_id": "fake-account",
+	}
+	if signatureID != "" {
+		metadata["related_signature_id"] = signatureID
+	}
+
+	return hellosign.Event{
+		Event: hellosign.EventInfo{
+			EventTime:     eventTime,
+			EventType:     eventType,
+			EventHash:     hellosign.EventHash(s.APIKey, eventTime, eventType),
+			EventMetadata: metadata,
+		},
+		SignatureRequest: request,
+	}
+}
+
+// notify records the event and posts it to CallbackURL when one is set.
+func (s *Server) notify(eventType string, request *hellosign.SignatureRequest, signatureID string) error {
+	event := s.newEvent(eventType, request, signatureID)
+
+	s.mu.Lock()
+	s.events = append(s.events, event)
+	callbackURL := s.CallbackURL
+	s.mu.Unlock()
+
+	if callbackURL == "" {
+		return nil
+	}
+	return s.post(callbackURL, event)
+}
+
+func (s *Server) post(callbackURL string, event hellosign.Event) error {
+	data, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+
+	response, err := http.PostForm(callbackURL, url.Values{"json": {string(data)}})
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode >= 300 {
+		return fmt.Errorf("hellosigntest: callback returned status %d", response.StatusCode)
+	}
+	return nil
+}
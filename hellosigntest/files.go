@@ -0,0 +1,8 @@
+package hellosigntest
+
+import "fmt"
+
+// PDF returns a placeholder PDF naming name, for stubbing downloads.
+func PDF(name string) []byte {
+	return []byte(fmt.Sprintf("%%PDF-1.4\n%% hellosigntest %s\n%%%%EOF\n", name))
+}
@@ -0,0 +1,228 @@
+package hellosigntest
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"sync"
+
+	hellosign "github.com/jheth/hellosign-go-sdk"
+)
+
+// ErrNotStubbed is returned by Mock methods whose function field is nil.
+var ErrNotStubbed = errors.New("hellosigntest: method not stubbed")
+
+// Call is a method call recorded by Mock.
+type Call struct {
+	Method string
+	Args   []interface{}
+}
+
+// Mock implements hellosign.API without any HTTP. Each method records its
+// call and then delegates to the matching function field; methods whose field
+// is nil return zero values and an error wrapping ErrNotStubbed. Context
+// arguments are passed on but not recorded.
+//
+//	mock := &hellosigntest.Mock{
+//		GetSignatureRequestFunc: func(id string) (*hellosign.SignatureRequest, error) {
+//			return &hellosign.SignatureRequest{SignatureRequestID: id, IsComplete: true}, nil
+//		},
+//	}
+//	service := NewService(mock)
+type Mock struct {
+	CreateSignatureRequestFunc                func(request hellosign.CreationRequest) (*hellosign.SignatureRequest, error)
+	CreateEmbeddedSignatureRequestFunc        func(request hellosign.CreationRequest) (*hellosign.SignatureRequest, error)
+	CreateSignatureRequestContextFunc         func(ctx context.Context, request hellosign.CreationRequest) (*hellosign.SignatureRequest, error)
+	CreateEmbeddedSignatureRequestContextFunc func(ctx context.Context, request hellosign.CreationRequest) (*hellosign.SignatureRequest, error)
+	GetSignatureRequestFunc                   func(signatureRequestID string) (*hellosign.SignatureRequest, error)
+	ListSignatureRequestsFunc                 func() (*hellosign.ListResponse, error)
+	ListSignatureRequestsWithOptionsFunc      func(opts hellosign.ListOptions) (*hellosign.ListResponse, error)
+	UpdateSignatureRequestFunc                func(signatureRequestID string, signatureID string, email string) (*hellosign.SignatureRequest, error)
+	RemindSignatureRequestFunc                func(signatureRequestID string, email string) (*hellosign.SignatureRequest, error)
+	CancelSignatureRequestFunc                func(signatureRequestID string) (*http.Response, error)
+	GetPDFFunc                                func(signatureRequestID string) ([]byte, error)
+	GetFilesFunc                              func(signatureRequestID, fileType string) ([]byte, error)
+	GetDocumentsFunc                          func(ctx context.Context, signatureRequestID string, uploads ...string) ([]hellosign.Document, error)
+	SaveFileFunc                              func(signatureRequestID, fileType, destFilePath string) (os.FileInfo, error)
+	GetEmbeddedSignURLFunc                    func(signatureID string) (*hellosign.SignURLResponse, error)
+
+	mu    sync.Mutex
+	calls []Call
+}
+
+var _ hellosign.API = (*Mock)(nil)
+
+// Calls returns every recorded call, oldest first.
+func (m *Mock) Calls() []Call {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return append([]Call{}, m.calls...)
+}
+
+// CallsTo returns the recorded calls to method, oldest first.
+func (m *Mock) CallsTo(method string) []Call {
+	var calls []Call
+	for _, call := range m.Calls() {
+		if call.Method == method {
+			calls = append(calls, call)
+		}
+	}
+	return calls
+}
+
+// Reset forgets the recorded calls. Function fields are kept.
+func (m *Mock) Reset() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.calls = nil
+}
+
+func (m *Mock) record(method string, args ...interface{}) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.calls = append(m.calls, Call{Method: method, Args: args})
+}
+
+func notStubbed(method string) error {
+	return fmt.Errorf("%w: %s", ErrNotStubbed, method)
+}
+
+// CreateSignatureRequest implements hellosign.API.
+func (m *Mock) CreateSignatureRequest(request hellosign.CreationRequest) (*hellosign.SignatureRequest, error) {
+	m.record("CreateSignatureRequest", request)
+	if m.CreateSignatureRequestFunc == nil {
+		return nil, notStubbed("CreateSignatureRequest")
+	}
+	return m.CreateSignatureRequestFunc(request)
+}
+
+// CreateEmbeddedSignatureRequest implements hellosign.API.
+func (m *Mock) CreateEmbeddedSignatureRequest(request hellosign.CreationRequest) (*hellosign.SignatureRequest, error) {
+	m.record("CreateEmbeddedSignatureRequest", request)
+	if m.CreateEmbeddedSignatureRequestFunc == nil {
+		return nil, notStubbed("CreateEmbeddedSignatureRequest")
+	}
+	return m.CreateEmbeddedSignatureRequestFunc(request)
+}
+
+// CreateSignatureRequestContext implements hellosign.API.
+func (m *Mock) CreateSignatureRequestContext(ctx context.Context, request hellosign.CreationRequest) (*hellosign.SignatureRequest, error) {
+	m.record("CreateSignatureRequestContext", request)
+	if m.CreateSignatureRequestContextFunc == nil {
+		return nil, notStubbed("CreateSignatureRequestContext")
+	}
+	return m.CreateSignatureRequestContextFunc(ctx, request)
+}
+
+// CreateEmbeddedSignatureRequestContext implements hellosign.API.
+func (m *Mock) CreateEmbeddedSignatureRequestContext(ctx context.Context, request hellosign.CreationRequest) (*hellosign.SignatureRequest, error) {
+	m.record("CreateEmbeddedSignatureRequestContext", request)
+	if m.CreateEmbeddedSignatureRequestContextFunc == nil {
+		return nil, notStubbed("CreateEmbeddedSignatureRequestContext")
+	}
+	return m.CreateEmbeddedSignatureRequestContextFunc(ctx, request)
+}
+
+// GetSignatureRequest implements hellosign.API.
+func (m *Mock) GetSignatureRequest(signatureRequestID string) (*hellosign.SignatureRequest, error) {
+	m.record("GetSignatureRequest", signatureRequestID)
+	if m.GetSignatureRequestFunc == nil {
+		return nil, notStubbed("GetSignatureRequest")
+	}
+	return m.GetSignatureRequestFunc(signatureRequestID)
+}
+
+// ListSignatureRequests implements hellosign.API.
+func (m *Mock) ListSignatureRequests() (*hellosign.ListResponse, error) {
+	m.record("ListSignatureRequests")
+	if m.ListSignatureRequestsFunc == nil {
+		return nil, notStubbed("ListSignatureRequests")
+	}
+	return m.ListSignatureRequestsFunc()
+}
+
+// ListSignatureRequestsWithOptions implements hellosign.API.
+func (m *Mock) ListSignatureRequestsWithOptions(opts hellosign.ListOptions) (*hellosign.ListResponse, error) {
+	m.record("ListSignatureRequestsWithOptions", opts)
+	if m.ListSignatureRequestsWithOptionsFunc == nil {
+		return nil, notStubbed("ListSignatureRequestsWithOptions")
+	}
+	return m.ListSignatureRequestsWithOptionsFunc(opts)
+}
+
+// UpdateSignatureRequest implements hellosign.API.
+func (m *Mock) UpdateSignatureRequest(signatureRequestID string, signatureID string, email string) (*hellosign.SignatureRequest, error) {
+	m.record("UpdateSignatureRequest", signatureRequestID, signatureID, email)
+	if m.UpdateSignatureRequestFunc == nil {
+		return nil, notStubbed("UpdateSignatureRequest")
+	}
+	return m.UpdateSignatureRequestFunc(signatureRequestID, signatureID, email)
+}
+
+// RemindSignatureRequest implements hellosign.API.
+func (m *Mock) RemindSignatureRequest(signatureRequestID string, email string) (*hellosign.SignatureRequest, error) {
+	m.record("RemindSignatureRequest", signatureRequestID, email)
+	if m.RemindSignatureRequestFunc == nil {
+		return nil, notStubbed("RemindSignatureRequest")
+	}
+	return m.RemindSignatureRequestFunc(signatureRequestID, email)
+}
+
+// CancelSignatureRequest implements hellosign.API.
+func (m *Mock) CancelSignatureRequest(signatureRequestID string) (*http.Response, error) {
+	m.record("CancelSignatureRequest", signatureRequestID)
+	if m.CancelSignatureRequestFunc == nil {
+		return nil, notStubbed("CancelSignatureRequest")
+	}
+	return m.CancelSignatureRequestFunc(signatureRequestID)
+}
+
+// GetPDF implements hellosign.API.
+func (m *Mock) GetPDF(signatureRequestID string) ([]byte, error) {
+	m.record("GetPDF", signatureRequestID)
+	if m.GetPDFFunc == nil {
+		return nil, notStubbed("GetPDF")
+	}
+	return m.GetPDFFunc(signatureRequestID)
+}
+
+// GetFiles implements hellosign.API.
+func (m *Mock) GetFiles(signatureRequestID, fileType string) ([]byte, error) {
+	m.record("GetFiles", signatureRequestID, fileType)
+	if m.GetFilesFunc == nil {
+		return nil, notStubbed("GetFiles")
+	}
+	return m.GetFilesFunc(signatureRequestID, fileType)
+}
+
+// SaveFile implements hellosign.API.
+func (m *Mock) SaveFile(signatureRequestID, fileType, destFilePath string) (os.FileInfo, error) {
+	m.record("SaveFile", signatureRequestID, fileType, destFilePath)
+	if m.SaveFileFunc == nil {
+		return nil, notStubbed("SaveFile")
+	}
+	return m.SaveFileFunc(signatureRequestID, fileType, destFilePath)
+}
+
+// GetDocuments implements hellosign.API.
+func (m *Mock) GetDocuments(ctx context.Context, signatureRequestID string, uploads ...string) ([]hellosign.Document, error) {
+	m.record("GetDocuments", signatureRequestID, uploads)
+	if m.GetDocumentsFunc == nil {
+		return nil, notStubbed("GetDocuments")
+	}
+	return m.GetDocumentsFunc(ctx, signatureRequestID, uploads...)
+}
+
+// GetEmbeddedSignURL implements hellosign.API.
+func (m *Mock) GetEmbeddedSignURL(signatureID string) (*hellosign.SignURLResponse, error) {
+	m.record("GetEmbeddedSignURL", signatureID)
+	if m.GetEmbeddedSignURLFunc == nil {
+		return nil, notStubbed("GetEmbeddedSignURL")
+	}
+	return m.GetEmbeddedSignURLFunc(signatureID)
+}
@@ -0,0 +1,80 @@
+package hellosigntest
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"testing"
+
+	hellosign "github.com/jheth/hellosign-go-sdk"
+	"github.com/stretchr/testify/assert"
+)
+
+func remindAll(api hellosign.SignatureRequestGetter, id string) ([]string, error) {
+	res, err := api.GetSignatureRequest(id)
+	if err != nil {
+		return nil, err
+	}
+
+	var emails []string
+	for _, signer := range res.PendingSigners() {
+		emails = append(emails, signer.SignerEmailAddress)
+	}
+	return emails, nil
+}
+
+func TestMock(t *testing.T) {
+	assert := assert.New(t)
+
+	mock := &Mock{
+		GetSignatureRequestFunc: func(id string) (*hellosign.SignatureRequest, error) {
+			return &hellosign.SignatureRequest{
+				SignatureRequestID: id,
+				Signatures: []*hellosign.Signature{
+					{SignerEmailAddress: "jane@example.com", StatusCode: hellosign.StatusSigned},
+					{SignerEmailAddress: "john@example.com", StatusCode: hellosign.StatusAwaitingSignature},
+				},
+			}, nil
+		},
+	}
+
+	emails, err := remindAll(mock, "abc")
+	assert.Nil(err, "Should not return error")
+	assert.Equal([]string{"john@example.com"}, emails)
+
+	_, err = mock.CreateEmbeddedSignatureRequest(hellosign.CreationRequest{Title: "Offer"})
+	assert.True(errors.Is(err, ErrNotStubbed))
+	assert.Equal("hellosigntest: method not stubbed: CreateEmbeddedSignatureRequest", err.Error())
+
+	assert.Equal([]Call{
+		{Method: "GetSignatureRequest", Args: []interface{}{"abc"}},
+		{Method: "CreateEmbeddedSignatureRequest", Args: []interface{}{hellosign.CreationRequest{Title: "Offer"}}},
+	}, mock.Calls())
+	assert.Equal(1, len(mock.CallsTo("GetSignatureRequest")))
+
+	mock.Reset()
+	assert.Empty(mock.Calls())
+}
+
+func TestMockDocuments(t *testing.T) {
+	assert := assert.New(t)
+
+	mock := &Mock{
+		GetDocumentsFunc: func(ctx context.Context, id string, uploads ...string) ([]hellosign.Document, error) {
+			return []hellosign.Document{hellosign.NewDocument("offer_letter.pdf", PDF("offer_letter.pdf"))}, nil
+		},
+	}
+
+	documents, err := mock.GetDocuments(context.Background(), "abc", "offer_letter.pdf")
+	if !assert.Nil(err, "Should not return error") {
+		return
+	}
+	reader, err := documents[0].Open()
+	assert.Nil(err, "Should not return error")
+	data, _ := ioutil.ReadAll(reader)
+	assert.Equal(PDF("offer_letter.pdf"), data)
+
+	assert.Equal([]Call{
+		{Method: "GetDocuments", Args: []interface{}{"abc", []string{"offer_letter.pdf"}}},
+	}, mock.Calls())
+}
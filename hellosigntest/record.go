@@ -0,0 +1,31 @@
+package hellosigntest
+
+import (
+	"os"
+	"testing"
+
+	hellosign "github.com/jheth/hellosign-go-sdk"
+	"github.com/jheth/hellosign-go-sdk/hellosigntest/recording"
+)
+
+// Record returns a client whose requests are recorded to, or replayed from,
+// the cassette at path. See package recording for when each happens and what
+// is scrubbed. The cassette is saved when the test finishes.
+func Record(t testing.TB, path string) *hellosign.Client {
+	t.Helper()
+
+	vcr, err := recording.NewRecorder(path)
+	if err != nil {
+		t.Fatalf("hellosigntest: loading cassette %s: %v", path, err)
+	}
+	t.Cleanup(func() {
+		if err := vcr.Stop(); err != nil {
+			t.Errorf("hellosigntest: saving cassette %s: %v", path, err)
+		}
+	})
+
+	return &hellosign.Client{
+		APIKey:     os.Getenv(recording.APIKeyEnv),
+		HTTPClient: recording.HTTPClient(vcr),
+	}
+}
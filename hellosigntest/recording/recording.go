@@ -0,0 +1,197 @@
+// Package recording records HelloSign API interactions into go-vcr cassettes
+// with secrets removed.
+//
+// Existing cassettes are replayed. Missing cassettes are recorded against the
+// real API using HELLOSIGN_API_KEY; set HELLOSIGN_RERECORD as well to record
+// existing cassettes again. Before a cassette is saved
+// the Authorization header and API key are removed, email addresses are
+// replaced with stable placeholders, presigned URL tokens are redacted and
+// large document bodies are trimmed.
+package recording
+
+import (
+	"fmt"
+	"net/http"
+	"os"
+	"regexp"
+	"strings"
+
+	"github.com/dnaeon/go-vcr/cassette"
+	"github.com/dnaeon/go-vcr/recorder"
+)
+
+// APIKeyEnv names the environment variable holding the key used for recording.
+const APIKeyEnv = "HELLOSIGN_API_KEY"
+
+// RerecordEnv names the environment variable that, when set to a non-empty
+// value, records existing cassettes again instead of replaying them.
+const RerecordEnv = "HELLOSIGN_RERECORD"
+
+// Redacted replaces secret values in saved cassettes.
+const Redacted = "REDACTED"
+
+// DefaultMaxBodySize is the largest document body kept verbatim in a cassette.
+const DefaultMaxBodySize = 16 << 10
+
+var (
+	emailPattern  = regexp.MustCompile(`[A-Za-z0-9._%+\-]+@[A-Za-z0-9.\-]+\.[A-Za-z]{2,}`)
+	tokenPattern  = regexp.MustCompile(`(?i)((?:token|signature|x-amz-signature|x-amz-credential|x-amz-security-token|awsaccesskeyid)=)[^&"'\s\\]+`)
+	binaryPrefix  = []string{"%PDF", "PK\x03\x04"}
+	binaryContent = []string{"application/pdf", "application/zip", "application/octet-stream"}
+)
+
+// Scrubber removes secrets from cassette interactions before they are saved.
+type Scrubber struct {
+	// APIKey is replaced wherever it appears. The Authorization header is always removed.
+	APIKey string
+	// KeepEmails disables email replacement.
+	KeepEmails bool
+	// MaxBodySize trims document bodies larger than this many bytes. Zero keeps every body.
+	MaxBodySize int
+
+	emails map[string]string
+}
+
+// NewScrubber returns a Scrubber with the default settings for apiKey.
+func NewScrubber(apiKey string) *Scrubber {
+	return &Scrubber{APIKey: apiKey, MaxBodySize: DefaultMaxBodySize}
+}
+
+// Filter implements cassette.Filter.
+func (s *Scrubber) Filter(i *cassette.Interaction) error {
+	i.Request.Headers = s.headers(i.Request.Headers)
+	i.Response.Headers = s.headers(i.Response.Headers)
+
+	i.Request.URL = s.text(i.Request.URL)
+	i.Request.Body = s.body(i.Request.Body, i.Request.Headers)
+	i.Response.Body = s.body(i.Response.Body, i.Response.Headers)
+
+	for key, values := range i.Request.Form {
+		for n, value := range values {
+			values[n] = s.text(value)
+		}
+		if name := s.text(key); name != key {
+			delete(i.Request.Form, key)
+			i.Request.Form[name] = values
+		}
+	}
+	return nil
+}
+
+func (s *Scrubber) headers(h http.Header) http.Header {
+	clean := http.Header{}
+	for key, values := range h {
+		switch http.CanonicalHeaderKey(key) {
+		case "Authorization", "Cookie", "Set-Cookie":
+			continue
+		}
+		for _, value := range values {
+			clean.Add(key, s.text(value))
+		}
+	}
+	return clean
+}
+
+func (s *Scrubber) body(body string, h http.Header) string {
+	if s.MaxBodySize > 0 && len(body) > s.MaxBodySize && isBinary(body, h) {
+		return fmt.Sprintf("%s trimmed %d bytes", binaryMarker(body), len(body))
+	}
+	if strings.HasPrefix(h.Get("Content-Type"), "multipart/form-data") && s.MaxBodySize > 0 && len(body) > s.MaxBodySize {
+		return fmt.Sprintf("multipart body trimmed %d bytes", len(body))
+	}
+	return s.text(body)
+}
+
+func (s *Scrubber) text(value string) string {
+	if s.APIKey != "" {
+		value = strings.Replace(value, s.APIKey, Redacted, -1)
+	}
+	value = tokenPattern.ReplaceAllString(value, "${1}"+Redacted)
+	if !s.KeepEmails {
+		value = emailPattern.ReplaceAllStringFunc(value, s.email)
+	}
+	return value
+}
+
+// email maps each address to a stable placeholder so requests and responses stay consistent.
+func (s *Scrubber) email(address string) string {
+	if strings.HasSuffix(address, "@example.com") {
+		return address
+	}
+	if s.emails == nil {
+		s.emails = map[string]string{}
+	}
+
+	key := strings.ToLower(address)
+	if placeholder, ok := s.emails[key]; ok {
+		return placeholder
+	}
+	placeholder := fmt.Sprintf("user%d@example.com", len(s.emails)+1)
+	s.emails[key] = placeholder
+	return placeholder
+}
+
+func isBinary(body string, h http.Header) bool {
+	for _, prefix := range binaryPrefix {
+		if strings.HasPrefix(body, prefix) {
+			return true
+		}
+	}
+	contentType := h.Get("Content-Type")
+	for _, binary := range binaryContent {
+		if strings.HasPrefix(contentType, binary) {
+			return true
+		}
+	}
+	return false
+}
+
+func binaryMarker(body string) string {
+	if strings.HasPrefix(body, "%PDF") {
+		return "%PDF-1.4 %%EOF"
+	}
+	return "[binary]"
+}
+
+// NewRecorder returns a recorder for the cassette at path, without the .yaml
+// extension. It replays the cassette if it exists, and records it against the
+// real API when it is missing or HELLOSIGN_RERECORD is set. Recording requires
+// HELLOSIGN_API_KEY. Stop must be called to save recordings.
+func NewRecorder(path string) (*recorder.Recorder, error) {
+	apiKey := os.Getenv(APIKeyEnv)
+
+	mode, err := recordingMode(path, apiKey, os.Getenv(RerecordEnv) != "")
+	if err != nil {
+		return nil, err
+	}
+
+	vcr, err := recorder.NewAsMode(path, mode, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	vcr.AddSaveFilter(NewScrubber(apiKey).Filter)
+	return vcr, nil
+}
+
+// recordingMode replays the cassette at path unless it is missing or
+// rerecord is set.
+func recordingMode(path, apiKey string, rerecord bool) (recorder.Mode, error) {
+	_, err := os.Stat(path + ".yaml")
+	switch {
+	case err == nil && !rerecord:
+		return recorder.ModeReplaying, nil
+	case err != nil && !os.IsNotExist(err):
+		return 0, err
+	case apiKey == "" && rerecord:
+		return 0, fmt.Errorf("recording: %s requires %s", RerecordEnv, APIKeyEnv)
+	case apiKey == "":
+		return 0, fmt.Errorf("recording: cassette %s.yaml not found; set %s to record it", path, APIKeyEnv)
+	}
+	return recorder.ModeRecording, nil
+}
+
+// HTTPClient returns an http.Client that sends requests through vcr.
+func HTTPClient(vcr *recorder.Recorder) *http.Client {
+	return &http.Client{Transport: vcr}
+}
@@ -0,0 +1,88 @@
+package recording
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/dnaeon/go-vcr/cassette"
+	"github.com/dnaeon/go-vcr/recorder"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestScrubberFilter(t *testing.T) {
+	assert := assert.New(t)
+
+	interaction := &cassette.Interaction{
+		Request: cassette.Request{
+			URL:     "https://api.hellosign.com/v3/signature_request/list?api_key=secret-key",
+			Body:    "signers[0][email_address]=Jane@Corp.com&signers[1][email_address]=bob@corp.com",
+			Form:    url.Values{"signers[0][email_address]": {"jane@corp.com"}},
+			Headers: http.Header{"Authorization": {"Basic c2VjcmV0LWtleTo="}, "Content-Type": {"application/x-www-form-urlencoded"}},
+		},
+		Response: cassette.Response{
+			Body:    `{"embedded":{"sign_url":"https:\/\/app.hellosign.com\/editor\/embeddedSign?signature_id=deaf&token=abc123"},"signer_email_address":"jane@corp.com","requester":"owner@example.com"}`,
+			Headers: http.Header{"Set-Cookie": {"session=1"}, "Content-Type": {"application/json"}},
+		},
+	}
+
+	assert.Nil(NewScrubber("secret-key").Filter(interaction))
+
+	assert.Equal("https://api.hellosign.com/v3/signature_request/list?api_key=REDACTED", interaction.Request.URL)
+	assert.Equal("signers[0][email_address]=user1@example.com&signers[1][email_address]=user2@example.com", interaction.Request.Body)
+	assert.Equal(url.Values{"signers[0][email_address]": {"user1@example.com"}}, interaction.Request.Form)
+	assert.Empty(interaction.Request.Headers.Get("Authorization"))
+	assert.Equal("application/x-www-form-urlencoded", interaction.Request.Headers.Get("Content-Type"))
+	assert.Empty(interaction.Response.Headers.Get("Set-Cookie"))
+	assert.Equal(`{"embedded":{"sign_url":"https:\/\/app.hellosign.com\/editor\/embeddedSign?signature_id=deaf&token=REDACTED"},"signer_email_address":"user1@example.com","requester":"owner@example.com"}`, interaction.Response.Body)
+}
+
+func TestScrubberTrimsDocuments(t *testing.T) {
+	assert := assert.New(t)
+
+	pdf := "%PDF-1.4\n" + strings.Repeat("x", DefaultMaxBodySize)
+	interaction := &cassette.Interaction{
+		Response: cassette.Response{
+			Body:    pdf,
+			Headers: http.Header{"Content-Type": {"application/pdf"}},
+		},
+	}
+
+	assert.Nil(NewScrubber("").Filter(interaction))
+	assert.Equal("%PDF-1.4 %%EOF trimmed 16393 bytes", interaction.Response.Body)
+
+	small := &cassette.Interaction{Response: cassette.Response{Body: "%PDF-1.4 small"}}
+	assert.Nil(NewScrubber("").Filter(small))
+	assert.Equal("%PDF-1.4 small", small.Response.Body)
+}
+
+func TestRecordingMode(t *testing.T) {
+	assert := assert.New(t)
+
+	dir := t.TempDir()
+	existing := filepath.Join(dir, "existing")
+	assert.Nil(ioutil.WriteFile(existing+".yaml", []byte("version: 1\ninteractions: []\n"), 0644))
+	missing := filepath.Join(dir, "missing")
+
+	mode, err := recordingMode(existing, "secret-key", false)
+	assert.Nil(err)
+	assert.Equal(recorder.ModeReplaying, mode, "existing cassettes are replayed even with a key")
+	mode, err = recordingMode(existing, "", false)
+	assert.Nil(err)
+	assert.Equal(recorder.ModeReplaying, mode)
+
+	mode, err = recordingMode(existing, "secret-key", true)
+	assert.Nil(err)
+	assert.Equal(recorder.ModeRecording, mode)
+	_, err = recordingMode(existing, "", true)
+	assert.EqualError(err, "recording: HELLOSIGN_RERECORD requires HELLOSIGN_API_KEY")
+
+	mode, err = recordingMode(missing, "secret-key", false)
+	assert.Nil(err)
+	assert.Equal(recorder.ModeRecording, mode)
+	_, err = recordingMode(missing, "", false)
+	assert.EqualError(err, "recording: cassette "+missing+".yaml not found; set HELLOSIGN_API_KEY to record it")
+}
@@ -0,0 +1,187 @@
+// Package hellosigntest provides an in-memory HelloSign API for tests.
+//
+// A Server answers the endpoints used by hellosign.Client from an
+// httptest.Server, keeps signature requests and templates in memory, and lets
+// tests move requests along by signing or declining them, which also delivers
+// callback events to a configured URL. Signature requests are handled by a
+// hellosign.DryRun, so the server and Client.DryRun behave the same.
+//
+//	server := hellosigntest.NewServer()
+//	defer server.Close()
+//
+//	client := server.Client()
+//	res, _ := client.CreateEmbeddedSignatureRequest(request)
+//	server.Sign(res.SignatureRequestID, res.Signatures[0].SignatureID, nil)
+package hellosigntest
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"path"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	hellosign "github.com/jheth/hellosign-go-sdk"
+)
+
+// APIKey is the key accepted by a Server unless Server.APIKey is changed.
+const APIKey = "hellosigntest-api-key"
+
+// Template is a template returned by the fake template endpoints.
+type Template struct {
+	TemplateID  string       `json:"template_id"`
+	Title       string       `json:"title"`
+	Message     string       `json:"message"`
+	SignerRoles []SignerRole `json:"signer_roles"`
+	CCRoles     []SignerRole `json:"cc_roles"`
+}
+
+// SignerRole is a role defined on a Template.
+type SignerRole struct {
+	Name  string `json:"name"`
+	Order int    `json:"order,omitempty"`
+}
+
+// Server is a fake HelloSign API.
+type Server struct {
+	*httptest.Server
+
+	// APIKey is required as the Basic auth user name. Empty disables auth.
+	APIKey string
+	// CallbackURL receives events when requests change state. Empty disables callbacks.
+	CallbackURL string
+	// Now returns the current time for timestamps. Defaults to time.Now.
+	Now func() time.Time
+
+	dryRun *hellosign.DryRun
+
+	mu        sync.Mutex
+	templates map[string]*Template
+	events    []hellosign.Event
+}
+
+// NewServer starts a fake HelloSign API. Call Close when done.
+func NewServer() *Server {
+	s := &Server{
+		APIKey:    APIKey,
+		Now:       time.Now,
+		templates: map[string]*Template{},
+	}
+	s.dryRun = &hellosign.DryRun{
+		Now:     func() time.Time { return s.Now() },
+		OnEvent: s.notify,
+	}
+	s.Server = httptest.NewServer(http.HandlerFunc(s.serveHTTP))
+	return s
+}
+
+// Client returns a hellosign.Client configured to talk to the server.
+func (s *Server) Client() *hellosign.Client {
+	return &hellosign.Client{
+		APIKey:     s.APIKey,
+		BaseURL:    s.URL + "/v3/",
+		HTTPClient: s.Server.Client(),
+	}
+}
+
+// AddTemplate stores a template. A template ID is generated when empty.
+func (s *Server) AddTemplate(t Template) *Template {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if t.TemplateID == "" {
+		t.TemplateID = newID(20)
+	}
+	s.templates[t.TemplateID] = &t
+	return &t
+}
+
+// SignatureRequests returns copies of every stored signature request, newest first.
+func (s *Server) SignatureRequests() []*hellosign.SignatureRequest {
+	return s.dryRun.SignatureRequests()
+}
+
+// SignatureRequest returns a copy of the stored signature request.
+func (s *Server) SignatureRequest(id string) (*hellosign.SignatureRequest, bool) {
+	return s.dryRun.SignatureRequest(id)
+}
+
+// Sign marks the signature as signed and records the given form field
+// responses keyed by api_id. The request completes once every signer has signed.
+func (s *Server) Sign(signatureRequestID, signatureID string, responses map[string]interface{}) error {
+	return s.dryRun.Sign(signatureRequestID, signatureID, responses)
+}
+
+// Decline marks the signature, and therefore the request, as declined.
+func (s *Server) Decline(signatureRequestID, signatureID, reason string) error {
+	return s.dryRun.Decline(signatureRequestID, signatureID, reason)
+}
+
+func (s *Server) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	if s.APIKey != "" {
+		if user, _, ok := r.BasicAuth(); !ok || user != s.APIKey {
+			writeError(w, http.StatusUnauthorized, "unauthorized", "Unauthorized api key")
+			return
+		}
+	}
+
+	route := strings.TrimPrefix(path.Clean(r.URL.Path), "/v3/")
+	switch {
+	case r.Method == http.MethodGet && route == "template/list":
+		s.listTemplates(w)
+	case r.Method == http.MethodGet && strings.HasPrefix(route, "template/"):
+		s.getTemplate(w, strings.TrimPrefix(route, "template/"))
+	default:
+		s.dryRun.ServeHTTP(w, r)
+	}
+}
+
+func (s *Server) listTemplates(w http.ResponseWriter) {
+	s.mu.Lock()
+	templates := []*Template{}
+	for _, t := range s.templates {
+		templates = append(templates, t)
+	}
+	s.mu.Unlock()
+
+	sort.Slice(templates, func(i, j int) bool { return templates[i].TemplateID < templates[j].TemplateID })
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"list_info": &hellosign.ListInfo{NumPages: 1, NumResults: len(templates), Page: 1, PageSize: len(templates)},
+		"templates": templates,
+	})
+}
+
+func (s *Server) getTemplate(w http.ResponseWriter, id string) {
+	s.mu.Lock()
+	t, ok := s.templates[id]
+	s.mu.Unlock()
+
+	if !ok {
+		writeError(w, http.StatusNotFound, "not_found", "Not found")
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]interface{}{"template": t})
+}
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
+
+func writeError(w http.ResponseWriter, status int, name, message string) {
+	writeJSON(w, status, &hellosign.ErrorResponse{
+		Error: &hellosign.Error{Name: name, Message: message},
+	})
+}
+
+func newID(size int) string {
+	b := make([]byte, size)
+	rand.Read(b)
+	return hex.EncodeToString(b)
+}
@@ -0,0 +1,337 @@
+package hellosigntest
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"strings"
+	"sync"
+	"testing"
+
+	hellosign "github.com/jheth/hellosign-go-sdk"
+	"github.com/jheth/hellosign-go-sdk/hellosigntest/recording"
+	"github.com/stretchr/testify/assert"
+)
+
+func creationRequest() hellosign.CreationRequest {
+	return hellosign.CreationRequest{
+		TestMode: true,
+		File:     []string{"../fixtures/offer_letter.pdf"},
+		Title:    "Offer",
+		Subject:  "Please sign",
+		Signers: []hellosign.Signer{
+			{Email: "jane@example.com", Name: "Jane Doe", Order: 0},
+			{Email: "john@example.com", Name: "John Doe", Order: 1},
+		},
+		Metadata: map[string]string{"contract_id": "42"},
+	}
+}
+
+func TestServerSignatureRequestLifecycle(t *testing.T) {
+	assert := assert.New(t)
+
+	server := NewServer()
+	defer server.Close()
+	client := server.Client()
+
+	res, err := client.CreateEmbeddedSignatureRequest(creationRequest())
+	if !assert.Nil(err, "Should not return error") {
+		return
+	}
+	assert.Equal("Offer", res.Title)
+	assert.Equal("42", res.Metadata["contract_id"])
+	assert.Equal(2, len(res.Signatures))
+	assert.Equal(hellosign.StatusAwaitingSignature, res.Signatures[0].StatusCode)
+	assert.Equal(hellosign.RequestStatusAwaitingSignature, res.OverallStatus())
+
+	jane := res.Signatures[0].SignatureID
+	john := res.Signatures[1].SignatureID
+
+	assert.Nil(server.Sign(res.SignatureRequestID, jane, map[string]interface{}{"agree": true}))
+
+	res, err = client.GetSignatureRequest(res.SignatureRequestID)
+	assert.Nil(err, "Should not return error")
+	assert.Equal(hellosign.RequestStatusPartiallySigned, res.OverallStatus())
+	assert.Equal(john, res.NextSigner().SignatureID)
+	assert.NotNil(res.SignerByEmail("jane@example.com").SignedAt)
+
+	agreed, _ := res.ResponseByID("agree").Value.Bool()
+	assert.True(agreed)
+
+	assert.Nil(server.Sign(res.SignatureRequestID, john, nil))
+	assert.NotNil(server.Sign(res.SignatureRequestID, john, nil), "Should not sign twice")
+
+	res, err = client.GetSignatureRequest(res.SignatureRequestID)
+	assert.Nil(err, "Should not return error")
+	assert.True(res.IsComplete)
+
+	url, err := client.GetEmbeddedSignURL(jane)
+	assert.Nil(err, "Should not return error")
+	assert.Contains(url.SignURL, "signature_id="+jane)
+	assert.False(url.Expired())
+
+	list, err := client.ListSignatureRequests()
+	assert.Nil(err, "Should not return error")
+	assert.Equal(1, list.ListInfo.NumResults)
+
+	var types []string
+	for _, event := range server.Events() {
+		types = append(types, event.Event.EventType)
+	}
+	assert.Equal([]string{
+		hellosign.EventSignatureRequestSent,
+		hellosign.EventSignatureRequestSigned,
+		hellosign.EventSignatureRequestSigned,
+		hellosign.EventSignatureRequestAllSigned,
+	}, types)
+}
+
+func TestServerGroupSigners(t *testing.T) {
+	assert := assert.New(t)
+
+	server := NewServer()
+	defer server.Close()
+	client := server.Client()
+
+	group := hellosign.Signer{Group: "Legal", GroupMembers: []hellosign.Signer{
+		{Email: "a@example.com", Name: "Alice"},
+		{Email: "b@example.com", Name: "Bob"},
+	}}
+
+	// Uploads are sent as multipart, file URLs as JSON.
+	multipart := creationRequest()
+	multipart.Signers = []hellosign.Signer{group}
+	byURL := creationRequest()
+	byURL.File, byURL.FileURL = nil, []string{"https://example.com/offer_letter.pdf"}
+	byURL.Signers = []hellosign.Signer{group}
+
+	for _, request := range []hellosign.CreationRequest{multipart, byURL} {
+		res, err := client.CreateSignatureRequest(request)
+		if !assert.Nil(err, "Should not return error") {
+			continue
+		}
+		assert.Equal(1, len(res.Signatures))
+		assert.Equal("Legal", res.Signatures[0].SignerName)
+		assert.Equal("a@example.com", res.Signatures[0].SignerEmailAddress)
+	}
+}
+
+func TestServerUpdateCancelAndDecline(t *testing.T) {
+	assert := assert.New(t)
+
+	server := NewServer()
+	defer server.Close()
+	client := server.Client()
+
+	res, err := client.CreateSignatureRequest(creationRequest())
+	if !assert.Nil(err, "Should not return error") {
+		return
+	}
+
+	updated, err := client.UpdateSignatureRequest(res.SignatureRequestID, res.Signatures[0].SignatureID, "franky@example.com")
+	assert.Nil(err, "Should not return error")
+	assert.Equal("franky@example.com", updated.Signatures[0].SignerEmailAddress)
+
+	assert.Nil(server.Decline(res.SignatureRequestID, res.Signatures[1].SignatureID, "Wrong salary"))
+	declined, _ := server.SignatureRequest(res.SignatureRequestID)
+	assert.Equal(hellosign.RequestStatusDeclined, declined.OverallStatus())
+	assert.Equal("Wrong salary", declined.Signatures[1].DeclineReason)
+
+	response, err := client.CancelSignatureRequest(res.SignatureRequestID)
+	assert.Nil(err, "Should not return error")
+	assert.Equal(200, response.StatusCode)
+
+	_, err = client.UpdateSignatureRequest(res.SignatureRequestID, res.Signatures[0].SignatureID, "joe@example.com")
+	assert.Equal("deleted: This resource has been deleted", err.Error())
+}
+
+func TestServerFiles(t *testing.T) {
+	assert := assert.New(t)
+
+	server := NewServer()
+	defer server.Close()
+	client := server.Client()
+
+	request := creationRequest()
+	request.File = []string{"../fixtures/offer_letter.pdf", "../fixtures/offer_letter.pdf"}
+	res, err := client.CreateEmbeddedSignatureRequest(request)
+	if !assert.Nil(err, "Should not return error") {
+		return
+	}
+
+	pdf, err := client.GetPDF(res.SignatureRequestID)
+	assert.Nil(err, "Should not return error")
+	assert.True(bytes.HasPrefix(pdf, []byte("%PDF")))
+
+	data, err := client.GetFiles(res.SignatureRequestID, "zip")
+	assert.Nil(err, "Should not return error")
+
+	archive, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if assert.Nil(err, "Should not return error") {
+		assert.Equal(2, len(archive.File))
+		assert.Equal("offer_letter.pdf", archive.File[0].Name)
+	}
+
+	request.File = []string{"../fixtures/offer_letter_signed.pdf", "../fixtures/offer_letter.pdf"}
+	res, err = client.CreateEmbeddedSignatureRequest(request)
+	if !assert.Nil(err, "Should not return error") {
+		return
+	}
+
+	documents, err := client.GetDocuments(context.Background(), res.SignatureRequestID, request.File...)
+	if assert.Nil(err, "Should not return error") && assert.Equal(2, len(documents)) {
+		assert.Equal("offer_letter_signed.pdf", documents[0].Name)
+		assert.Equal("../fixtures/offer_letter_signed.pdf", documents[0].Upload)
+		assert.Equal("offer_letter.pdf", documents[1].Name)
+
+		reader, err := documents[1].Open()
+		assert.Nil(err, "Should not return error")
+		data, _ := ioutil.ReadAll(reader)
+		reader.Close()
+		assert.True(bytes.HasPrefix(data, []byte("%PDF")))
+		assert.Contains(string(data), "offer_letter.pdf")
+	}
+}
+
+func TestServerErrors(t *testing.T) {
+	assert := assert.New(t)
+
+	server := NewServer()
+	defer server.Close()
+
+	client := server.Client()
+	client.APIKey = "wrong"
+	_, err := client.UpdateSignatureRequest("missing", "missing", "joe@example.com")
+	assert.Equal("unauthorized: Unauthorized api key", err.Error())
+
+	client.APIKey = APIKey
+	_, err = client.UpdateSignatureRequest("missing", "missing", "joe@example.com")
+	assert.Equal("not_found: Not found", err.Error())
+}
+
+func TestServerCallbacks(t *testing.T) {
+	assert := assert.New(t)
+
+	var mu sync.Mutex
+	received := []*hellosign.Event{}
+	callback := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+		event, err := hellosign.ParseEvent(body)
+		if !assert.Nil(err) {
+			return
+		}
+
+		mu.Lock()
+		received = append(received, event)
+		mu.Unlock()
+
+		w.Write([]byte(hellosign.EventReceived))
+	}))
+	defer callback.Close()
+
+	server := NewServer()
+	defer server.Close()
+	server.CallbackURL = callback.URL
+
+	res, err := server.Client().CreateEmbeddedSignatureRequest(creationRequest())
+	if !assert.Nil(err, "Should not return error") {
+		return
+	}
+	assert.Nil(server.Decline(res.SignatureRequestID, res.Signatures[0].SignatureID, "No thanks"))
+	assert.Nil(server.DeliverEvent(callback.URL, hellosign.EventCallbackTest, res.SignatureRequestID))
+
+	mu.Lock()
+	defer mu.Unlock()
+	if assert.Equal(3, len(received)) {
+		assert.Equal(hellosign.EventSignatureRequestSent, received[0].Event.EventType)
+		assert.Equal(hellosign.EventSignatureRequestDeclined, received[1].Event.EventType)
+		assert.Equal(res.Signatures[0].SignatureID, received[1].Event.EventMetadata["related_signature_id"])
+		assert.True(received[1].SignatureRequest.IsDeclined)
+		assert.Equal(hellosign.EventCallbackTest, received[2].Event.EventType)
+		assert.True(received[1].Verify(APIKey))
+	}
+}
+
+func TestServerTemplates(t *testing.T) {
+	assert := assert.New(t)
+
+	server := NewServer()
+	defer server.Close()
+
+	tmpl := server.AddTemplate(Template{Title: "NDA", SignerRoles: []SignerRole{{Name: "Client"}}})
+
+	request, _ := http.NewRequest("GET", server.URL+"/v3/template/"+tmpl.TemplateID, nil)
+	request.SetBasicAuth(APIKey, "")
+	response, err := http.DefaultClient.Do(request)
+	if !assert.Nil(err, "Should not return error") {
+		return
+	}
+	defer response.Body.Close()
+
+	body := struct {
+		Template Template `json:"template"`
+	}{}
+	assert.Nil(json.NewDecoder(response.Body).Decode(&body))
+	assert.Equal("NDA", body.Template.Title)
+	assert.Equal("Client", body.Template.SignerRoles[0].Name)
+}
+
+func TestServerRemindAndQuery(t *testing.T) {
+	assert := assert.New(t)
+
+	server := NewServer()
+	defer server.Close()
+	client := server.Client()
+
+	res, err := client.CreateEmbeddedSignatureRequest(creationRequest())
+	if !assert.Nil(err, "Should not return error") {
+		return
+	}
+
+	call := func(method, route string, form url.Values) (int, []byte) {
+		request, _ := http.NewRequest(method, server.URL+"/v3/"+route, strings.NewReader(form.Encode()))
+		request.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		request.SetBasicAuth(APIKey, "")
+		response, err := http.DefaultClient.Do(request)
+		if !assert.Nil(err, "Should not return error") {
+			return 0, nil
+		}
+		defer response.Body.Close()
+		data, _ := ioutil.ReadAll(response.Body)
+		return response.StatusCode, data
+	}
+
+	status, _ := call("POST", "signature_request/remind/"+res.SignatureRequestID, url.Values{"email_address": {"JOHN@example.com"}})
+	assert.Equal(200, status)
+	request, _ := server.SignatureRequest(res.SignatureRequestID)
+	assert.NotNil(request.SignerByEmail("john@example.com").LastRemindedAt)
+	assert.Equal(hellosign.EventSignatureRequestReminded, server.Events()[len(server.Events())-1].Event.EventType)
+
+	status, _ = call("POST", "signature_request/remind/"+res.SignatureRequestID, url.Values{"email_address": {"nobody@example.com"}})
+	assert.Equal(400, status)
+
+	for query, count := range map[string]int{"": 1, "title:offer": 1, "jane@example.com": 1, "metadata:42": 1, "title:nda": 0} {
+		_, data := call("GET", "signature_request/list?"+url.Values{"query": {query}}.Encode(), nil)
+		list := hellosign.ListResponse{}
+		assert.Nil(json.Unmarshal(data, &list))
+		assert.Equal(count, list.ListInfo.NumResults, query)
+	}
+}
+
+func TestRecordReplaysCassette(t *testing.T) {
+	if os.Getenv(recording.RerecordEnv) != "" {
+		t.Skip("recording against the live API")
+	}
+
+	client := Record(t, "../fixtures/get_signature_request")
+
+	res, err := client.GetSignatureRequest("6d7ad140141a7fe6874fec55931c363e0301c353")
+	assert.Nil(t, err, "Should not return error")
+	assert.Equal(t, "awesome", res.Subject)
+}
@@ -0,0 +1,153 @@
+package hellosign
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"io"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// IdempotencyKeyMetadata is the metadata key holding CreationRequest.IdempotencyKey.
+const IdempotencyKeyMetadata = "idempotency_key"
+
+// IdempotencyPolicy configures creates that carry an IdempotencyKey.
+//
+// When such a create fails without a clear answer from HelloSign (a
+// connection error, a timeout, a truncated response or a 5xx status) the
+// request may or may not exist. The client then searches recent signature
+// requests for the key and returns the existing one if found, and only sends
+// the create again when it is not.
+type IdempotencyPolicy struct {
+	// MaxRetries is the number of times the create is sent again after an
+	// uncertain failure. With zero, the client only searches for the key.
+	MaxRetries int
+	// Backoff returns the delay before search n, starting at 1, giving
+	// HelloSign time to list a request it created. Defaults to 500ms doubled
+	// on each search.
+	Backoff func(n int) time.Duration
+	// LookupFirst also searches before the first attempt, for callers
+	// repeating a create themselves with the same key.
+	LookupFirst bool
+	// SearchTimeout bounds the search made after the caller's context ended
+	// during an uncertain create. Defaults to 10s.
+	SearchTimeout time.Duration
+}
+
+// NewIdempotencyKey returns a random key for CreationRequest.IdempotencyKey.
+// Keep it with the record the request is created for, so a repeated create
+// reuses it.
+func NewIdempotencyKey() string {
+	b := make([]byte, 16)
+	rand.Read(b)
+	return hex.EncodeToString(b)
+}
+
+// FindByIdempotencyKey - Returns the signature request created with key, or
+// nil when none of the recent requests carries it.
+func (m *Client) FindByIdempotencyKey(key string) (*SignatureRequest, error) {
+	return m.findByIdempotencyKey(context.Background(), key)
+}
+
+func (m *Client) findByIdempotencyKey(ctx context.Context, key string) (*SignatureRequest, error) {
+	res, err := m.listSignatureRequests(ctx, ListOptions{Query: "metadata:" + key, PageSize: 100})
+	if err != nil {
+		return nil, err
+	}
+	// The search is fuzzy, so check the key exactly.
+	for _, request := range res.SignatureRequests {
+		if value, ok := request.Metadata[IdempotencyKeyMetadata].(string); ok && value == key {
+			return request, nil
+		}
+	}
+	return nil, nil
+}
+
+// createIdempotent stamps the key into the metadata and creates the request
+// at most once, as far as the search can tell.
+func (m *Client) createIdempotent(ctx context.Context, path string, request CreationRequest) (*SignatureRequest, error) {
+	metadata := map[string]string{IdempotencyKeyMetadata: request.IdempotencyKey}
+	for key, value := range request.Metadata {
+		if key != IdempotencyKeyMetadata {
+			metadata[key] = value
+		}
+	}
+	request.Metadata = metadata
+	if err := request.Validate(); err != nil {
+		return nil, err
+	}
+
+	policy := m.Idempotency
+	if policy.Backoff == nil {
+		policy.Backoff = func(n int) time.Duration {
+			return 500 * time.Millisecond << uint(n-1)
+		}
+	}
+	if policy.SearchTimeout == 0 {
+		policy.SearchTimeout = 10 * time.Second
+	}
+
+	if policy.LookupFirst {
+		existing, err := m.findByIdempotencyKey(ctx, request.IdempotencyKey)
+		if err != nil || existing != nil {
+			return existing, err
+		}
+	}
+
+	for n := 1; ; n++ {
+		created, err := m.create(ctx, path, request)
+		if err == nil || !uncertain(err) {
+			return created, err
+		}
+
+		existing, lookupErr := m.lookupAfter(ctx, policy, n, request.IdempotencyKey)
+		if existing != nil {
+			return existing, nil
+		}
+		// Without an answer from the search, sending again could duplicate
+		// the request; return the original error so the caller retries later.
+		if lookupErr != nil || n > policy.MaxRetries || ctx.Err() != nil {
+			return nil, err
+		}
+	}
+}
+
+// lookupAfter waits for backoff n, then searches for key. When ctx ends
+// first, as when the caller's deadline cut the create short, it skips the
+// rest of the wait and searches once on a context of its own, bounded by
+// SearchTimeout: the create may still have gone through.
+func (m *Client) lookupAfter(ctx context.Context, policy IdempotencyPolicy, n int, key string) (*SignatureRequest, error) {
+	if ctx.Err() == nil && sleep(ctx, policy.Backoff(n)) == nil {
+		return m.findByIdempotencyKey(ctx, key)
+	}
+	ctx, cancel := context.WithTimeout(detachedContext{ctx}, policy.SearchTimeout)
+	defer cancel()
+	return m.findByIdempotencyKey(ctx, key)
+}
+
+// detachedContext keeps the values of a context, such as the current span,
+// but not its deadline or cancellation.
+type detachedContext struct {
+	context.Context
+}
+
+func (detachedContext) Deadline() (time.Time, bool) { return time.Time{}, false }
+func (detachedContext) Done() <-chan struct{}       { return nil }
+func (detachedContext) Err() error                  { return nil }
+
+// uncertain reports whether a create that failed with err may still have
+// been carried out by HelloSign: the connection failed or timed out, the
+// response was cut short, or HelloSign answered with a 5xx status. Local
+// failures, such as a File that can't be read, never reached HelloSign.
+func uncertain(err error) bool {
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		return apiErr.StatusCode >= http.StatusInternalServerError
+	}
+	// http.Client reports every failure to send or receive as a *url.Error.
+	var urlErr *url.Error
+	return errors.As(err, &urlErr) || errors.Is(err, io.ErrUnexpectedEOF)
+}
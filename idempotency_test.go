@@ -0,0 +1,194 @@
+package hellosign
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// lostResponses fails the first n creates after they reached the server, as
+// when a timeout hits while HelloSign is answering.
+func lostResponses(n int, reached bool) Middleware {
+	return func(next http.RoundTripper) http.RoundTripper {
+		return RoundTripFunc(func(request *http.Request) (*http.Response, error) {
+			if request.Method != http.MethodPost || n == 0 {
+				return next.RoundTrip(request)
+			}
+			n--
+			if reached {
+				response, err := next.RoundTrip(request)
+				if err != nil {
+					return nil, err
+				}
+				response.Body.Close()
+			}
+			return nil, &url.Error{Op: "Post", URL: request.URL.String(), Err: errors.New("net/http: timeout awaiting response headers")}
+		})
+	}
+}
+
+func idempotentClient(middleware Middleware) (*Client, *DryRun) {
+	dryRun := NewDryRun("")
+	client := &Client{
+		APIKey:      "secret-key",
+		DryRun:      dryRun,
+		Middleware:  []Middleware{middleware},
+		Idempotency: IdempotencyPolicy{MaxRetries: 2, Backoff: func(int) time.Duration { return 0 }},
+	}
+	return client, dryRun
+}
+
+func idempotentRequest(key string) CreationRequest {
+	return CreationRequest{
+		TestMode:       true,
+		FileURL:        []string{"https://example.com/offer_letter.pdf"},
+		Signers:        []Signer{{Name: "Jane Doe", Email: "jane@example.com"}},
+		Metadata:       map[string]string{"employee_id": "42"},
+		IdempotencyKey: key,
+	}
+}
+
+func TestIdempotentCreateFindsExisting(t *testing.T) {
+	assert := assert.New(t)
+
+	client, dryRun := idempotentClient(lostResponses(1, true))
+	key := NewIdempotencyKey()
+
+	res, err := client.CreateSignatureRequest(idempotentRequest(key))
+	assert.Nil(err)
+	assert.Equal(key, res.Metadata[IdempotencyKeyMetadata])
+	assert.Equal("42", res.Metadata["employee_id"])
+
+	list, _ := client.ListSignatureRequests()
+	assert.Equal(1, list.ListInfo.NumResults)
+
+	paths := []string{}
+	for _, request := range dryRun.Requests() {
+		paths = append(paths, request.Path)
+	}
+	assert.Equal([]string{
+		"signature_request/send",
+		"signature_request/list?page_size=100&query=metadata%3A" + key,
+		"signature_request/list",
+	}, paths)
+}
+
+func TestIdempotentCreateResends(t *testing.T) {
+	assert := assert.New(t)
+
+	client, _ := idempotentClient(lostResponses(1, false))
+
+	res, err := client.CreateSignatureRequest(idempotentRequest("key-1"))
+	assert.Nil(err)
+	assert.Equal("key-1", res.Metadata[IdempotencyKeyMetadata])
+
+	list, _ := client.ListSignatureRequests()
+	assert.Equal(1, list.ListInfo.NumResults)
+}
+
+func TestIdempotentCreateGivesUp(t *testing.T) {
+	assert := assert.New(t)
+
+	client, dryRun := idempotentClient(lostResponses(5, false))
+
+	_, err := client.CreateSignatureRequest(idempotentRequest("key-1"))
+	assert.EqualError(err, `Post "https://api.hellosign.com/v3/signature_request/send": net/http: timeout awaiting response headers`)
+	// Three creates were lost, each followed by a search.
+	assert.Len(dryRun.Requests(), 3)
+}
+
+func TestIdempotentCreateLookupFirst(t *testing.T) {
+	assert := assert.New(t)
+
+	client, dryRun := idempotentClient(lostResponses(0, false))
+	first, err := client.CreateSignatureRequest(idempotentRequest("key-1"))
+	assert.Nil(err)
+
+	client.Idempotency.LookupFirst = true
+	again, err := client.CreateSignatureRequest(idempotentRequest("key-1"))
+	assert.Nil(err)
+	assert.Equal(first.SignatureRequestID, again.SignatureRequestID)
+	assert.Len(dryRun.Requests(), 2)
+}
+
+func TestIdempotentCreateCertainFailure(t *testing.T) {
+	assert := assert.New(t)
+
+	client, dryRun := idempotentClient(func(next http.RoundTripper) http.RoundTripper {
+		return RoundTripFunc(func(request *http.Request) (*http.Response, error) {
+			return &http.Response{
+				StatusCode: http.StatusBadRequest,
+				Header:     http.Header{},
+				Body:       http.NoBody,
+				Request:    request,
+			}, nil
+		})
+	})
+
+	_, err := client.CreateSignatureRequest(idempotentRequest("key-1"))
+	assert.IsType(&APIError{}, err)
+	assert.Empty(dryRun.Requests())
+}
+
+func TestIdempotentCreateLocalFailure(t *testing.T) {
+	assert := assert.New(t)
+
+	client, dryRun := idempotentClient(lostResponses(0, false))
+	request := idempotentRequest("key-1")
+	request.FileURL = nil
+	request.File = []string{"fixtures/missing.pdf"}
+
+	_, err := client.CreateSignatureRequest(request)
+	assert.NotNil(err)
+	assert.Empty(dryRun.Requests(), "a create that never left the client is not searched for")
+}
+
+func TestIdempotentCreateContext(t *testing.T) {
+	assert := assert.New(t)
+
+	client, dryRun := idempotentClient(lostResponses(1, false))
+	client.Idempotency.Backoff = func(int) time.Duration { return time.Hour }
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	_, err := client.CreateSignatureRequestContext(ctx, idempotentRequest("key-1"))
+	assert.EqualError(err, `Post "https://api.hellosign.com/v3/signature_request/send": net/http: timeout awaiting response headers`,
+		"the create's error, not the deadline's")
+	if assert.Len(dryRun.Requests(), 1) {
+		assert.Contains(dryRun.Requests()[0].Path, "signature_request/list", "searched without resending")
+	}
+}
+
+func TestIdempotentCreateCanceledDuringCreate(t *testing.T) {
+	assert := assert.New(t)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	client, _ := idempotentClient(func(next http.RoundTripper) http.RoundTripper {
+		return RoundTripFunc(func(request *http.Request) (*http.Response, error) {
+			if request.Method == http.MethodGet {
+				assert.Nil(request.Context().Err(), "the search isn't bound to the canceled context")
+				return next.RoundTrip(request)
+			}
+			// The create reaches HelloSign, then the caller gives up.
+			response, err := next.RoundTrip(request)
+			if err != nil {
+				return nil, err
+			}
+			response.Body.Close()
+			cancel()
+			return nil, &url.Error{Op: "Post", URL: request.URL.String(), Err: context.Canceled}
+		})
+	})
+
+	res, err := client.CreateSignatureRequestContext(ctx, idempotentRequest("key-1"))
+	assert.Nil(err)
+	if assert.NotNil(res) {
+		assert.Equal("key-1", res.Metadata[IdempotencyKeyMetadata])
+	}
+}
@@ -0,0 +1,123 @@
+package hellosign
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// Call describes an API call to a Tracer or Metrics.
+type Call struct {
+	Method   string // HTTP method.
+	Endpoint string // Path template with IDs replaced, e.g. "signature_request/{id}". Low cardinality, suitable as a span name or metric label.
+	Path     string // Actual path, e.g. "signature_request/fa5c8a0b0f492d768749333ad6fcc214c111e967".
+}
+
+// CallResult is the outcome of an API call.
+type CallResult struct {
+	StatusCode int           // HTTP status, or zero when no response was received.
+	Duration   time.Duration // Time until the response headers were received, including retries.
+	Retries    int           // Number of times the request was retried.
+	RequestID  string        // HelloSign's request ID, when returned.
+	ErrorName  string        // HelloSign error_name for API errors, e.g. "not_found".
+	Err        error         // The error returned to the caller, if any.
+}
+
+// Tracer starts a span for each API call. The context returned is used for
+// the request so transports can propagate it. The otelhellosign module
+// implements Tracer and Metrics with OpenTelemetry.
+type Tracer interface {
+	StartSpan(ctx context.Context, call Call) (context.Context, Span)
+}
+
+// Span is ended once the call completes.
+type Span interface {
+	End(result CallResult)
+}
+
+// Metrics records the latency, count and errors of API calls.
+type Metrics interface {
+	RecordCall(ctx context.Context, call Call, result CallResult)
+}
+
+// fixedRoutes are endpoints without an ID in their path.
+var fixedRoutes = map[string]bool{
+	"signature_request/send":            true,
+	"signature_request/create_embedded": true,
+	"signature_request/list":            true,
+	"template/list":                     true,
+}
+
+type retriesKey struct{}
+
+// contextWithRetries returns a context carrying a retry counter for the call.
+func contextWithRetries(ctx context.Context) context.Context {
+	return context.WithValue(ctx, retriesKey{}, new(int))
+}
+
+// countRetry records that the request using ctx is being retried.
+func countRetry(ctx context.Context) {
+	if retries, ok := ctx.Value(retriesKey{}).(*int); ok {
+		*retries++
+	}
+}
+
+func retries(ctx context.Context) int {
+	if retries, ok := ctx.Value(retriesKey{}).(*int); ok {
+		return *retries
+	}
+	return 0
+}
+
+func (m *Client) newCall(request *http.Request) Call {
+	path := strings.TrimPrefix(request.URL.Path, "/")
+	if base, err := url.Parse(m.getEndpoint()); err == nil {
+		path = strings.TrimPrefix(request.URL.Path, base.Path)
+	}
+	return Call{Method: request.Method, Endpoint: endpointTemplate(path), Path: path}
+}
+
+// endpointTemplate replaces the trailing ID of path with {id}.
+func endpointTemplate(path string) string {
+	if fixedRoutes[path] {
+		return path
+	}
+	if i := strings.LastIndex(path, "/"); i >= 0 {
+		return path[:i] + "/{id}"
+	}
+	return path
+}
+
+func (m *Client) startSpan(ctx context.Context, call Call) (context.Context, Span) {
+	ctx = contextWithRetries(ctx)
+	if m.Tracer == nil {
+		return ctx, nil
+	}
+	return m.Tracer.StartSpan(ctx, call)
+}
+
+func (m *Client) finishCall(ctx context.Context, span Span, call Call, response *http.Response, duration time.Duration, err error) {
+	if span == nil && m.Metrics == nil {
+		return
+	}
+
+	result := CallResult{Duration: duration, Retries: retries(ctx), Err: err}
+	if response != nil {
+		result.StatusCode = response.StatusCode
+		result.RequestID = response.Header.Get(requestIDHeader)
+	}
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		result.ErrorName = apiErr.Name
+	}
+
+	if span != nil {
+		span.End(result)
+	}
+	if m.Metrics != nil {
+		m.Metrics.RecordCall(ctx, call, result)
+	}
+}
@@ -0,0 +1,102 @@
+package hellosign
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type ctxKey struct{}
+
+type testSpan struct {
+	call   Call
+	result *CallResult
+}
+
+func (s *testSpan) End(result CallResult) {
+	s.result = &result
+}
+
+type testTracer struct {
+	spans []*testSpan
+}
+
+func (t *testTracer) StartSpan(ctx context.Context, call Call) (context.Context, Span) {
+	span := &testSpan{call: call}
+	t.spans = append(t.spans, span)
+	return context.WithValue(ctx, ctxKey{}, span), span
+}
+
+type testMetrics struct {
+	calls   []Call
+	results []CallResult
+}
+
+func (m *testMetrics) RecordCall(ctx context.Context, call Call, result CallResult) {
+	m.calls = append(m.calls, call)
+	m.results = append(m.results, result)
+}
+
+func TestClientInstrumentation(t *testing.T) {
+	assert := assert.New(t)
+
+	var spanInRequest interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Request-Id", "req-1")
+		if r.URL.Path == "/v3/signature_request/missing" {
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"error":{"error_msg":"Not found","error_name":"not_found"}}`))
+			return
+		}
+		w.Write([]byte(`{"list_info":{},"signature_requests":[]}`))
+	}))
+	defer server.Close()
+
+	tracer := &testTracer{}
+	metrics := &testMetrics{}
+	client := &Client{BaseURL: server.URL + "/v3/", Tracer: tracer, Metrics: metrics}
+	client.Hooks.OnRequest = func(r *http.Request) {
+		spanInRequest = r.Context().Value(ctxKey{})
+	}
+
+	_, err := client.ListSignatureRequestsWithOptions(ListOptions{Page: 2})
+	assert.Nil(err, "Should not return error")
+
+	_, err = client.GetSignatureRequest("missing")
+	var apiErr *APIError
+	if assert.True(errors.As(err, &apiErr)) {
+		assert.Equal(404, apiErr.StatusCode)
+		assert.Equal("not_found", apiErr.Name)
+		assert.Equal("Not found", apiErr.Message)
+	}
+
+	if !assert.Equal(2, len(tracer.spans)) {
+		return
+	}
+	assert.Equal(Call{Method: "GET", Endpoint: "signature_request/list", Path: "signature_request/list"}, tracer.spans[0].call)
+	assert.Equal(200, tracer.spans[0].result.StatusCode)
+	assert.Equal("req-1", tracer.spans[0].result.RequestID)
+	assert.Nil(tracer.spans[0].result.Err)
+
+	assert.Equal(Call{Method: "GET", Endpoint: "signature_request/{id}", Path: "signature_request/missing"}, tracer.spans[1].call)
+	assert.Equal(404, tracer.spans[1].result.StatusCode)
+	assert.Equal("not_found", tracer.spans[1].result.ErrorName)
+	assert.Equal(err, tracer.spans[1].result.Err)
+	assert.Equal(tracer.spans[1], spanInRequest)
+
+	assert.Equal([]Call{tracer.spans[0].call, tracer.spans[1].call}, metrics.calls)
+	assert.Equal("not_found", metrics.results[1].ErrorName)
+}
+
+func TestEndpointTemplate(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.Equal("signature_request/send", endpointTemplate("signature_request/send"))
+	assert.Equal("signature_request/{id}", endpointTemplate("signature_request/fa5c8a0b0f492d768749333ad6fcc214c111e967"))
+	assert.Equal("signature_request/files/{id}", endpointTemplate("signature_request/files/abc"))
+	assert.Equal("embedded/sign_url/{id}", endpointTemplate("embedded/sign_url/abc"))
+}
@@ -0,0 +1,208 @@
+package hellosign
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Logger receives structured log lines as a message followed by alternating
+// keys and values. *slog.Logger satisfies it.
+type Logger interface {
+	Debug(msg string, args ...interface{})
+	Info(msg string, args ...interface{})
+	Error(msg string, args ...interface{})
+}
+
+// Hooks are called around every API call. Any of them may be nil.
+type Hooks struct {
+	// OnRequest is called before each attempt is sent, including retries. It
+	// may add headers.
+	OnRequest func(request *http.Request)
+	// OnResponse is called for each attempt that receives a response, whatever
+	// its status.
+	OnResponse func(request *http.Request, response *http.Response, duration time.Duration)
+	// OnError is called once per failed call, after any retries, with the
+	// error the call returns: a transport error, or the *APIError for an
+	// error status. response is nil when nothing was received.
+	OnError func(request *http.Request, response *http.Response, err error)
+}
+
+// requestIDHeader carries HelloSign's identifier for a request, useful when contacting support.
+const requestIDHeader = "X-Request-Id"
+
+// redacted replaces secrets in debug output.
+const redacted = "REDACTED"
+
+// WithLogger - Logs every API call to logger. Set Debug to also log request bodies.
+func (m *Client) WithLogger(logger Logger) *Client {
+	m.Logger = logger
+
+	return m
+}
+
+// logging is the built-in middleware calling Hooks and logging each attempt.
+func (m *Client) logging(next http.RoundTripper) http.RoundTripper {
+	return RoundTripFunc(func(request *http.Request) (*http.Response, error) {
+		if m.Hooks.OnRequest != nil {
+			m.Hooks.OnRequest(request)
+		}
+		if m.Debug && m.Logger != nil {
+			m.Logger.Debug("hellosign request body",
+				"method", request.Method,
+				"path", request.URL.Path,
+				"body", m.dumpBody(request))
+		}
+
+		start := time.Now()
+		response, err := next.RoundTrip(request)
+		duration := time.Since(start)
+		if err != nil {
+			// Reported once for the whole call by send.
+			return nil, err
+		}
+
+		if m.Hooks.OnResponse != nil {
+			m.Hooks.OnResponse(request, response, duration)
+		}
+		if m.Logger != nil {
+			m.Logger.Info("hellosign request",
+				"method", request.Method,
+				"path", request.URL.Path,
+				"status", response.StatusCode,
+				"duration", duration,
+				"request_id", response.Header.Get(requestIDHeader))
+		}
+		return response, nil
+	})
+}
+
+// failed reports the error a call returns to OnError and the logger.
+func (m *Client) failed(request *http.Request, response *http.Response, duration time.Duration, err error) {
+	if m.Hooks.OnError != nil {
+		m.Hooks.OnError(request, response, err)
+	}
+	if m.Logger == nil {
+		return
+	}
+
+	args := []interface{}{"method", request.Method, "path", request.URL.Path, "duration", duration}
+	if response != nil {
+		args = append(args, "status", response.StatusCode, "request_id", response.Header.Get(requestIDHeader))
+	}
+	m.Logger.Error("hellosign request failed", append(args, "error", err.Error())...)
+}
+
+// dumpBody describes the request body for debugging without consuming it.
+// Uploaded file contents, PINs and the API key are redacted.
+func (m *Client) dumpBody(request *http.Request) string {
+	if request.GetBody == nil {
+		return ""
+	}
+	body, err := request.GetBody()
+	if err != nil {
+		return ""
+	}
+	defer body.Close()
+
+	var dump string
+	mediaType, params, _ := mime.ParseMediaType(request.Header.Get("Content-Type"))
+	switch mediaType {
+	case "multipart/form-data":
+		dump = dumpMultipart(multipart.NewReader(body, params["boundary"]))
+	case "application/json":
+		dump = dumpJSON(body)
+	default:
+		data, _ := ioutil.ReadAll(body)
+		values, err := url.ParseQuery(string(data))
+		if err != nil {
+			return fmt.Sprintf("<%d bytes>", len(data))
+		}
+		fields := []string{}
+		for key, list := range values {
+			for _, value := range list {
+				fields = append(fields, dumpField(key, value))
+			}
+		}
+		sort.Strings(fields)
+		dump = strings.Join(fields, " ")
+	}
+
+	if m.APIKey != "" {
+		dump = strings.Replace(dump, m.APIKey, redacted, -1)
+	}
+	return dump
+}
+
+func dumpMultipart(reader *multipart.Reader) string {
+	fields := []string{}
+	for {
+		part, err := reader.NextPart()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			fields = append(fields, fmt.Sprintf("<invalid multipart body: %v>", err))
+			break
+		}
+
+		data, _ := ioutil.ReadAll(part)
+		if part.FileName() != "" {
+			fields = append(fields, fmt.Sprintf("%s=<file %s, %d bytes>", part.FormName(), part.FileName(), len(data)))
+			continue
+		}
+		fields = append(fields, dumpField(part.FormName(), string(data)))
+	}
+	return strings.Join(fields, " ")
+}
+
+func dumpJSON(body io.Reader) string {
+	var value interface{}
+	if err := json.NewDecoder(body).Decode(&value); err != nil {
+		return fmt.Sprintf("<invalid JSON body: %v>", err)
+	}
+
+	data, _ := json.Marshal(redactJSON(value))
+	return string(data)
+}
+
+func redactJSON(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for key, child := range v {
+			if isSecretField(key) {
+				v[key] = redacted
+				continue
+			}
+			v[key] = redactJSON(child)
+		}
+	case []interface{}:
+		for i, child := range v {
+			v[i] = redactJSON(child)
+		}
+	}
+	return value
+}
+
+func dumpField(name, value string) string {
+	if isSecretField(name) {
+		value = redacted
+	}
+	return fmt.Sprintf("%s=%q", name, value)
+}
+
+// isSecretField reports whether a parameter such as signers[0][pin] holds a secret.
+func isSecretField(name string) bool {
+	if i := strings.LastIndex(name, "["); i >= 0 {
+		name = strings.TrimSuffix(name[i+1:], "]")
+	}
+	return name == "pin" || name == "api_key"
+}
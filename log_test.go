@@ -0,0 +1,177 @@
+package hellosign
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type logLine struct {
+	level string
+	msg   string
+	args  map[string]interface{}
+}
+
+type testLogger struct {
+	lines []logLine
+}
+
+func (l *testLogger) log(level, msg string, args []interface{}) {
+	line := logLine{level: level, msg: msg, args: map[string]interface{}{}}
+	for i := 0; i+1 < len(args); i += 2 {
+		line.args[fmt.Sprint(args[i])] = args[i+1]
+	}
+	l.lines = append(l.lines, line)
+}
+
+func (l *testLogger) Debug(msg string, args ...interface{}) { l.log("debug", msg, args) }
+func (l *testLogger) Info(msg string, args ...interface{})  { l.log("info", msg, args) }
+func (l *testLogger) Error(msg string, args ...interface{}) { l.log("error", msg, args) }
+
+func TestClientLogging(t *testing.T) {
+	assert := assert.New(t)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Request-Id", "req-123")
+		if r.URL.Path == "/v3/signature_request/missing" {
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"error":{"error_msg":"Not found","error_name":"not_found"}}`))
+			return
+		}
+		w.Write([]byte(`{"signature_request":{"signature_request_id":"abc"}}`))
+	}))
+	defer server.Close()
+
+	logger := &testLogger{}
+	var requested, responded, failed []string
+	client := &Client{
+		APIKey:  "secret-key",
+		BaseURL: server.URL + "/v3/",
+		Debug:   true,
+		Hooks: Hooks{
+			OnRequest: func(r *http.Request) {
+				requested = append(requested, r.URL.Path)
+			},
+			OnResponse: func(r *http.Request, response *http.Response, duration time.Duration) {
+				responded = append(responded, fmt.Sprintf("%s %d", r.URL.Path, response.StatusCode))
+			},
+			OnError: func(r *http.Request, response *http.Response, err error) {
+				failed = append(failed, err.Error())
+			},
+		},
+	}
+	client.WithLogger(logger)
+
+	_, err := client.CreateEmbeddedSignatureRequest(CreationRequest{
+		ClientID: "secret-key-client",
+		File:     []string{"fixtures/offer_letter.pdf"},
+		Signers:  []Signer{{Name: "Jane", Email: "jane@example.com", Pin: "4321"}},
+	})
+	assert.Nil(err, "Should not return error")
+
+	_, err = client.GetSignatureRequest("missing")
+	assert.Equal("not_found: Not found", err.Error())
+
+	assert.Equal([]string{"/v3/signature_request/create_embedded", "/v3/signature_request/missing"}, requested)
+	assert.Equal([]string{"/v3/signature_request/create_embedded 200", "/v3/signature_request/missing 404"}, responded)
+	assert.Equal([]string{"not_found: Not found"}, failed)
+
+	if !assert.Equal(5, len(logger.lines)) {
+		return
+	}
+
+	body := logger.lines[0]
+	assert.Equal("debug", body.level)
+	assert.Contains(body.args["body"], `file[0]=<file offer_letter.pdf, `)
+	assert.Contains(body.args["body"], `signers[0][pin]="REDACTED"`)
+	assert.Contains(body.args["body"], `signers[0][email_address]="jane@example.com"`)
+	assert.Contains(body.args["body"], `client_id="REDACTED-client"`)
+	assert.NotContains(body.args["body"], "%PDF")
+
+	info := logger.lines[1]
+	assert.Equal("info", info.level)
+	assert.Equal("POST", info.args["method"])
+	assert.Equal("/v3/signature_request/create_embedded", info.args["path"])
+	assert.Equal(200, info.args["status"])
+	assert.Equal("req-123", info.args["request_id"])
+
+	failure := logger.lines[4]
+	assert.Equal("error", failure.level)
+	assert.Equal(404, failure.args["status"])
+	assert.Equal("not_found: Not found", failure.args["error"])
+}
+
+func TestClientTransportError(t *testing.T) {
+	assert := assert.New(t)
+
+	logger := &testLogger{}
+	var hookErr error
+	client := &Client{
+		BaseURL:    "http://hellosign.invalid/v3/",
+		HTTPClient: &http.Client{Transport: failingTransport{}},
+		Logger:     logger,
+		Hooks: Hooks{OnError: func(r *http.Request, response *http.Response, err error) {
+			assert.Nil(response)
+			hookErr = err
+		}},
+	}
+
+	_, err := client.ListSignatureRequests()
+	assert.NotNil(err)
+	assert.Contains(hookErr.Error(), "connection refused")
+	assert.Equal("error", logger.lines[0].level)
+}
+
+func TestHooksWithRetry(t *testing.T) {
+	assert := assert.New(t)
+
+	attempts := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		w.WriteHeader(http.StatusServiceUnavailable)
+		w.Write([]byte(`{"error":{"error_msg":"Try again","error_name":"unavailable"}}`))
+	}))
+	defer server.Close()
+
+	var requests, responses, errs int
+	hooks := Hooks{
+		OnRequest:  func(*http.Request) { requests++ },
+		OnResponse: func(*http.Request, *http.Response, time.Duration) { responses++ },
+		OnError:    func(*http.Request, *http.Response, error) { errs++ },
+	}
+	retry := Retry(RetryPolicy{MaxRetries: 2, Backoff: func(int) time.Duration { return 0 }})
+
+	client := &Client{APIKey: "secret-key", BaseURL: server.URL + "/v3/", Hooks: hooks, Middleware: []Middleware{retry}}
+	_, err := client.GetSignatureRequest("abc")
+	assert.IsType(&APIError{}, err)
+	assert.Equal(3, attempts)
+	assert.Equal([]int{3, 3, 1}, []int{requests, responses, errs}, "OnError fires once per call")
+
+	requests, responses, errs = 0, 0, 0
+	client = &Client{
+		BaseURL:    "http://hellosign.invalid/v3/",
+		HTTPClient: &http.Client{Transport: failingTransport{}},
+		Hooks:      hooks,
+		Middleware: []Middleware{retry},
+	}
+	_, err = client.GetSignatureRequest("abc")
+	assert.Contains(err.Error(), "connection refused")
+	assert.Equal([]int{3, 0, 1}, []int{requests, responses, errs}, "transport errors too")
+}
+
+func TestDumpJSONRedactsPins(t *testing.T) {
+	body, _ := marshalJSON(CreationRequest{Signers: []Signer{{Name: "Jane", Email: "jane@example.com", Pin: "4321"}}})
+	assert.Contains(t, dumpJSON(bytes.NewReader(body)), `"pin":"REDACTED"`)
+}
+
+type failingTransport struct{}
+
+func (failingTransport) RoundTrip(*http.Request) (*http.Response, error) {
+	return nil, errors.New("connection refused")
+}
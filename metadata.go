@@ -0,0 +1,202 @@
+package hellosign
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// metadataTag names the metadata key of a struct field. Options:
+//
+//	omitempty - skip zero values when encoding
+//	json      - store the value as a JSON string, for nested data
+const metadataTag = "metadata"
+
+type metadataField struct {
+	key   string
+	index int
+	opts  tagOptions
+}
+
+func metadataFields(t reflect.Type) []metadataField {
+	fields := []metadataField{}
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if field.PkgPath != "" {
+			continue
+		}
+
+		tag := field.Tag.Get(metadataTag)
+		if tag == "-" {
+			continue
+		}
+
+		parts := strings.Split(tag, ",")
+		key := parts[0]
+		if key == "" {
+			key = field.Name
+		}
+
+		opts := tagOptions{}
+		for _, opt := range parts[1:] {
+			switch opt {
+			case "omitempty":
+				opts.omitEmpty = true
+			case "json":
+				opts.json = true
+			}
+		}
+		fields = append(fields, metadataField{key: key, index: i, opts: opts})
+	}
+	return fields
+}
+
+// EncodeMetadata - Converts a struct into CreationRequest.Metadata using its `metadata` field tags.
+// Scalars are formatted as strings; fields tagged `metadata:"key,json"` are stored as JSON.
+// HelloSign's key count and length limits are enforced.
+func EncodeMetadata(v interface{}) (map[string]string, error) {
+	val := reflect.ValueOf(v)
+	for val.Kind() == reflect.Ptr {
+		if val.IsNil() {
+			return map[string]string{}, nil
+		}
+		val = val.Elem()
+	}
+	if val.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("hellosign: cannot encode %s as metadata", val.Type())
+	}
+
+	metadata := map[string]string{}
+	for _, field := range metadataFields(val.Type()) {
+		fieldVal := val.Field(field.index)
+		if field.opts.omitEmpty && isEmptyValue(fieldVal) {
+			continue
+		}
+
+		value, err := formatMetadataValue(fieldVal, field.opts)
+		if err != nil {
+			return nil, fmt.Errorf("metadata[%s]: %v", field.key, err)
+		}
+
+		if utf8.RuneCountInString(field.key) > MaxMetadataKeyLength {
+			return nil, fmt.Errorf("metadata[%s]: key is longer than %d characters", field.key, MaxMetadataKeyLength)
+		}
+		if utf8.RuneCountInString(value) > MaxMetadataValueLength {
+			return nil, fmt.Errorf("metadata[%s]: value is longer than %d characters", field.key, MaxMetadataValueLength)
+		}
+		metadata[field.key] = value
+	}
+
+	if len(metadata) > MaxMetadataKeys {
+		return nil, fmt.Errorf("metadata: at most %d keys are allowed, got %d", MaxMetadataKeys, len(metadata))
+	}
+	return metadata, nil
+}
+
+func formatMetadataValue(val reflect.Value, opts tagOptions) (string, error) {
+	if opts.json {
+		data, err := json.Marshal(val.Interface())
+		return string(data), err
+	}
+
+	switch val.Kind() {
+	case reflect.String:
+		return val.String(), nil
+	case reflect.Bool:
+		return strconv.FormatBool(val.Bool()), nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(val.Int(), 10), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return strconv.FormatUint(val.Uint(), 10), nil
+	case reflect.Float32, reflect.Float64:
+		return strconv.FormatFloat(val.Float(), 'f', -1, 64), nil
+	}
+	return "", fmt.Errorf("%s values must be tagged json", val.Type())
+}
+
+// DecodeMetadata - Fills the struct pointed to by dst from SignatureRequest.Metadata.
+// It is the reverse of EncodeMetadata; keys missing from metadata leave fields untouched.
+func DecodeMetadata(metadata map[string]interface{}, dst interface{}) error {
+	val := reflect.ValueOf(dst)
+	if val.Kind() != reflect.Ptr || val.IsNil() || val.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("hellosign: DecodeMetadata requires a non-nil struct pointer")
+	}
+	val = val.Elem()
+
+	for _, field := range metadataFields(val.Type()) {
+		raw, ok := metadata[field.key]
+		if !ok || raw == nil {
+			continue
+		}
+
+		if err := parseMetadataValue(val.Field(field.index), raw, field.opts); err != nil {
+			return fmt.Errorf("metadata[%s]: %v", field.key, err)
+		}
+	}
+	return nil
+}
+
+// DecodeMetadata - Fills the struct pointed to by dst from the request's metadata.
+func (s *SignatureRequest) DecodeMetadata(dst interface{}) error {
+	return DecodeMetadata(s.Metadata, dst)
+}
+
+func parseMetadataValue(field reflect.Value, raw interface{}, opts tagOptions) error {
+	var value string
+	switch v := raw.(type) {
+	case string:
+		value = v
+	case bool:
+		value = strconv.FormatBool(v)
+	case float64:
+		value = strconv.FormatFloat(v, 'f', -1, 64)
+	default:
+		if opts.json {
+			data, err := json.Marshal(v)
+			if err != nil {
+				return err
+			}
+			return json.Unmarshal(data, field.Addr().Interface())
+		}
+		return fmt.Errorf("unexpected %T value", raw)
+	}
+
+	if opts.json {
+		return json.Unmarshal([]byte(value), field.Addr().Interface())
+	}
+
+	switch field.Kind() {
+	case reflect.String:
+		field.SetString(value)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(value)
+		if err != nil {
+			return err
+		}
+		field.SetBool(b)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		i, err := strconv.ParseInt(value, 10, field.Type().Bits())
+		if err != nil {
+			return err
+		}
+		field.SetInt(i)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		u, err := strconv.ParseUint(value, 10, field.Type().Bits())
+		if err != nil {
+			return err
+		}
+		field.SetUint(u)
+	case reflect.Float32, reflect.Float64:
+		f, err := strconv.ParseFloat(value, field.Type().Bits())
+		if err != nil {
+			return err
+		}
+		field.SetFloat(f)
+	default:
+		return fmt.Errorf("%s values must be tagged json", field.Type())
+	}
+	return nil
+}
@@ -0,0 +1,72 @@
+package hellosign
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type contractMetadata struct {
+	ContractID int64             `metadata:"contract_id"`
+	Customer   string            `metadata:"customer"`
+	Renewal    bool              `metadata:"renewal"`
+	Discount   float64           `metadata:"discount,omitempty"`
+	Tags       []string          `metadata:"tags,json"`
+	Extra      map[string]string `metadata:"extra,json,omitempty"`
+	Internal   string            `metadata:"-"`
+}
+
+func TestEncodeMetadata(t *testing.T) {
+	assert := assert.New(t)
+
+	metadata, err := EncodeMetadata(&contractMetadata{
+		ContractID: 42,
+		Customer:   "Acme",
+		Renewal:    true,
+		Tags:       []string{"msa", "2021"},
+		Internal:   "secret",
+	})
+
+	assert.Nil(err, "Should not return error")
+	assert.Equal(map[string]string{
+		"contract_id": "42",
+		"customer":    "Acme",
+		"renewal":     "true",
+		"tags":        `["msa","2021"]`,
+	}, metadata)
+
+	_, err = EncodeMetadata(contractMetadata{Customer: strings.Repeat("a", MaxMetadataValueLength+1)})
+	assert.Equal("metadata[customer]: value is longer than 500 characters", err.Error())
+
+	_, err = EncodeMetadata(struct {
+		Nested struct{ ID int } `metadata:"nested"`
+	}{})
+	assert.Equal("metadata[nested]: struct { ID int } values must be tagged json", err.Error())
+}
+
+func TestDecodeMetadata(t *testing.T) {
+	assert := assert.New(t)
+
+	// Metadata as returned by the API after a round trip.
+	req := &SignatureRequest{}
+	err := json.Unmarshal([]byte(`{"metadata":{"contract_id":"42","customer":"Acme","renewal":"true","discount":"0.15","tags":"[\"msa\"]","unrelated":"x"}}`), req)
+	assert.Nil(err, "Should not return error")
+
+	dst := contractMetadata{Internal: "kept"}
+	assert.Nil(req.DecodeMetadata(&dst))
+
+	assert.Equal(contractMetadata{
+		ContractID: 42,
+		Customer:   "Acme",
+		Renewal:    true,
+		Discount:   0.15,
+		Tags:       []string{"msa"},
+		Internal:   "kept",
+	}, dst)
+
+	req.Metadata["contract_id"] = "abc"
+	assert.NotNil(req.DecodeMetadata(&dst))
+	assert.NotNil(req.DecodeMetadata(dst))
+}
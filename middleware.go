@@ -0,0 +1,161 @@
+package hellosign
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// RoundTripFunc adapts a function to http.RoundTripper.
+type RoundTripFunc func(request *http.Request) (*http.Response, error)
+
+// RoundTrip calls f(request).
+func (f RoundTripFunc) RoundTrip(request *http.Request) (*http.Response, error) {
+	return f(request)
+}
+
+// Middleware wraps the next step of the chain every API call goes through.
+// It may change the request, short-circuit with its own response, or call
+// next any number of times.
+type Middleware func(next http.RoundTripper) http.RoundTripper
+
+// WithMiddleware - Appends middleware to the chain. See Client.Middleware for the order.
+func (m *Client) WithMiddleware(middleware ...Middleware) *Client {
+	m.Middleware = append(m.Middleware, middleware...)
+
+	return m
+}
+
+// transport builds the chain: Middleware in order, then the built-in hooks
+// and logging, then authentication, then HTTPClient, or DryRun when set.
+func (m *Client) transport() http.RoundTripper {
+	var rt http.RoundTripper = RoundTripFunc(func(request *http.Request) (*http.Response, error) {
+		if m.DryRun != nil {
+			return m.DryRun.roundTrip(request, strings.TrimPrefix(request.URL.String(), m.getEndpoint()))
+		}
+		return m.getHTTPClient().Do(request)
+	})
+
+	rt = BasicAuth(m.APIKey)(rt)
+	rt = m.logging(rt)
+	for i := len(m.Middleware) - 1; i >= 0; i-- {
+		rt = m.Middleware[i](rt)
+	}
+	return rt
+}
+
+// BasicAuth authenticates requests with apiKey as the Basic auth user name,
+// as HelloSign expects. The client adds it for Client.APIKey.
+func BasicAuth(apiKey string) Middleware {
+	return func(next http.RoundTripper) http.RoundTripper {
+		return RoundTripFunc(func(request *http.Request) (*http.Response, error) {
+			request = request.Clone(request.Context())
+			request.SetBasicAuth(apiKey, "")
+			return next.RoundTrip(request)
+		})
+	}
+}
+
+// Headers sets the given headers on every request, e.g. a User-Agent.
+func Headers(header http.Header) Middleware {
+	return func(next http.RoundTripper) http.RoundTripper {
+		return RoundTripFunc(func(request *http.Request) (*http.Response, error) {
+			request = request.Clone(request.Context())
+			for key, values := range header {
+				request.Header[key] = append([]string(nil), values...)
+			}
+			return next.RoundTrip(request)
+		})
+	}
+}
+
+// RetryPolicy configures Retry.
+type RetryPolicy struct {
+	// MaxRetries is the number of retries after the first attempt.
+	MaxRetries int
+	// Backoff returns the delay before retry n, starting at 1. Defaults to
+	// 500ms doubled on each retry. A Retry-After header takes precedence.
+	Backoff func(n int) time.Duration
+	// ShouldRetry decides whether an attempt is retried. Defaults to
+	// DefaultShouldRetry.
+	ShouldRetry func(request *http.Request, response *http.Response, err error) bool
+}
+
+// DefaultShouldRetry retries rate limited requests, and GET requests that
+// failed to connect or got a 502, 503 or 504. Other methods are not retried
+// on those errors because HelloSign may already have acted on them.
+func DefaultShouldRetry(request *http.Request, response *http.Response, err error) bool {
+	if response != nil && response.StatusCode == http.StatusTooManyRequests {
+		return true
+	}
+	if request.Method != http.MethodGet {
+		return false
+	}
+	if err != nil {
+		return request.Context().Err() == nil
+	}
+	switch response.StatusCode {
+	case http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	}
+	return false
+}
+
+// Retry retries failed attempts according to policy. Retries are reported in
+// CallResult.Retries.
+func Retry(policy RetryPolicy) Middleware {
+	if policy.Backoff == nil {
+		policy.Backoff = func(n int) time.Duration {
+			return 500 * time.Millisecond << uint(n-1)
+		}
+	}
+	if policy.ShouldRetry == nil {
+		policy.ShouldRetry = DefaultShouldRetry
+	}
+
+	return func(next http.RoundTripper) http.RoundTripper {
+		return RoundTripFunc(func(request *http.Request) (*http.Response, error) {
+			for n := 1; ; n++ {
+				response, err := next.RoundTrip(request)
+				if n > policy.MaxRetries || !policy.ShouldRetry(request, response, err) {
+					return response, err
+				}
+
+				delay := policy.Backoff(n)
+				if response != nil {
+					if seconds, err := strconv.Atoi(response.Header.Get("Retry-After")); err == nil {
+						delay = time.Duration(seconds) * time.Second
+					}
+					response.Body.Close()
+				}
+				if err := sleep(request.Context(), delay); err != nil {
+					return nil, err
+				}
+
+				if request.GetBody != nil {
+					body, err := request.GetBody()
+					if err != nil {
+						return nil, err
+					}
+					request = request.Clone(request.Context())
+					request.Body = body
+				}
+				countRetry(request.Context())
+			}
+		})
+	}
+}
+
+func sleep(ctx context.Context, delay time.Duration) error {
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
@@ -0,0 +1,115 @@
+package hellosign
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func recordingMiddleware(name string, order *[]string) Middleware {
+	return func(next http.RoundTripper) http.RoundTripper {
+		return RoundTripFunc(func(request *http.Request) (*http.Response, error) {
+			*order = append(*order, name)
+			return next.RoundTrip(request)
+		})
+	}
+}
+
+func TestMiddlewareOrder(t *testing.T) {
+	assert := assert.New(t)
+
+	var order []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		user, _, _ := r.BasicAuth()
+		order = append(order, "server:"+user+":"+r.Header.Get("User-Agent"))
+		w.Write([]byte(`{"signature_request":{"signature_request_id":"abc"}}`))
+	}))
+	defer server.Close()
+
+	client := &Client{APIKey: "secret", BaseURL: server.URL + "/v3/"}
+	client.Hooks.OnRequest = func(r *http.Request) {
+		order = append(order, "hooks")
+	}
+	client.WithMiddleware(
+		recordingMiddleware("first", &order),
+		Headers(http.Header{"User-Agent": {"contracts/1.0"}}),
+		recordingMiddleware("second", &order),
+	)
+
+	_, err := client.GetSignatureRequest("abc")
+	assert.Nil(err, "Should not return error")
+	assert.Equal([]string{"first", "second", "hooks", "server:secret:contracts/1.0"}, order)
+}
+
+func TestMiddlewareShortCircuit(t *testing.T) {
+	assert := assert.New(t)
+
+	cached := func(next http.RoundTripper) http.RoundTripper {
+		return RoundTripFunc(func(request *http.Request) (*http.Response, error) {
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Header:     http.Header{},
+				Body:       ioutil.NopCloser(strings.NewReader(`{"signature_request":{"signature_request_id":"cached"}}`)),
+				Request:    request,
+			}, nil
+		})
+	}
+
+	client := &Client{BaseURL: "http://hellosign.invalid/v3/", HTTPClient: &http.Client{Transport: failingTransport{}}}
+	client.WithMiddleware(cached)
+
+	res, err := client.GetSignatureRequest("abc")
+	assert.Nil(err, "Should not return error")
+	assert.Equal("cached", res.SignatureRequestID)
+}
+
+func TestRetry(t *testing.T) {
+	assert := assert.New(t)
+
+	attempts := map[string]int{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts[r.Method+" "+r.URL.Path]++
+		body, _ := ioutil.ReadAll(r.Body)
+
+		switch {
+		case r.URL.Path == "/v3/signature_request/abc" && attempts["GET /v3/signature_request/abc"] < 3:
+			w.WriteHeader(http.StatusServiceUnavailable)
+		case r.URL.Path == "/v3/signature_request/update/abc" && attempts["POST /v3/signature_request/update/abc"] == 1:
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(http.StatusTooManyRequests)
+		case r.URL.Path == "/v3/signature_request/update/abc" && !strings.Contains(string(body), "joe@example.com"):
+			w.WriteHeader(http.StatusBadRequest)
+		case r.URL.Path == "/v3/signature_request/remind/abc":
+			w.WriteHeader(http.StatusServiceUnavailable)
+		default:
+			w.Write([]byte(`{"signature_request":{"signature_request_id":"abc"}}`))
+		}
+	}))
+	defer server.Close()
+
+	metrics := &testMetrics{}
+	client := &Client{BaseURL: server.URL + "/v3/", Metrics: metrics}
+	client.WithMiddleware(Retry(RetryPolicy{
+		MaxRetries: 3,
+		Backoff:    func(n int) time.Duration { return time.Millisecond },
+	}))
+
+	_, err := client.GetSignatureRequest("abc")
+	assert.Nil(err, "Should not return error")
+	assert.Equal(3, attempts["GET /v3/signature_request/abc"])
+	assert.Equal(2, metrics.results[0].Retries)
+
+	_, err = client.UpdateSignatureRequest("abc", "sig", "joe@example.com")
+	assert.Nil(err, "Should retry rate limited requests with their body")
+	assert.Equal(2, attempts["POST /v3/signature_request/update/abc"])
+	assert.Equal(1, metrics.results[1].Retries)
+
+	_, err = client.RemindSignatureRequest("abc", "joe@example.com")
+	assert.Equal("hellosign request failed with status 503", err.Error())
+	assert.Equal(1, attempts["POST /v3/signature_request/remind/abc"], "Should not retry POST on 503")
+}
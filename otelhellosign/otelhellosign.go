@@ -0,0 +1,140 @@
+// Package otelhellosign reports hellosign.Client calls to OpenTelemetry.
+//
+// It is a separate module so that the SDK itself doesn't depend on
+// OpenTelemetry:
+//
+//	client := hellosign.Client{
+//		APIKey:  "ACCOUNT API KEY",
+//		Tracer:  otelhellosign.NewTracer(otel.GetTracerProvider()),
+//		Metrics: metrics,
+//	}
+package otelhellosign
+
+import (
+	"context"
+
+	hellosign "github.com/jheth/hellosign-go-sdk"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// ScopeName is the instrumentation scope of the tracer and meter.
+const ScopeName = "github.com/jheth/hellosign-go-sdk/otelhellosign"
+
+// Attribute keys set on spans and metrics.
+const (
+	EndpointKey  = attribute.Key("hellosign.endpoint")   // Call.Endpoint, e.g. "signature_request/{id}".
+	RetriesKey   = attribute.Key("hellosign.retries")    // CallResult.Retries.
+	RequestIDKey = attribute.Key("hellosign.request_id") // CallResult.RequestID.
+	ErrorNameKey = attribute.Key("hellosign.error_name") // CallResult.ErrorName.
+	MethodKey    = attribute.Key("http.request.method")
+	StatusKey    = attribute.Key("http.response.status_code")
+)
+
+// Tracer starts a client span named "hellosign <endpoint>" for each call.
+type Tracer struct {
+	tracer trace.Tracer
+}
+
+var _ hellosign.Tracer = Tracer{}
+
+// NewTracer returns a Tracer using a tracer from provider.
+func NewTracer(provider trace.TracerProvider) Tracer {
+	return Tracer{tracer: provider.Tracer(ScopeName)}
+}
+
+// StartSpan implements hellosign.Tracer.
+func (t Tracer) StartSpan(ctx context.Context, call hellosign.Call) (context.Context, hellosign.Span) {
+	ctx, span := t.tracer.Start(ctx, "hellosign "+call.Endpoint,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(MethodKey.String(call.Method), EndpointKey.String(call.Endpoint)),
+	)
+	return ctx, otelSpan{span}
+}
+
+type otelSpan struct {
+	span trace.Span
+}
+
+func (s otelSpan) End(result hellosign.CallResult) {
+	s.span.SetAttributes(RetriesKey.Int(result.Retries))
+	if result.StatusCode != 0 {
+		s.span.SetAttributes(StatusKey.Int(result.StatusCode))
+	}
+	if result.RequestID != "" {
+		s.span.SetAttributes(RequestIDKey.String(result.RequestID))
+	}
+	if result.ErrorName != "" {
+		s.span.SetAttributes(ErrorNameKey.String(result.ErrorName))
+	}
+	if result.Err != nil {
+		s.span.RecordError(result.Err)
+		s.span.SetStatus(codes.Error, result.Err.Error())
+	}
+	s.span.End()
+}
+
+// Metrics records the duration of each call in the
+// hellosign.client.duration histogram, in seconds, its retries in the
+// hellosign.client.retries counter and, if it failed, counts it in the
+// hellosign.client.errors counter. Failed calls carry ErrorNameKey, or
+// error.type "transport" when no response was received.
+type Metrics struct {
+	duration metric.Float64Histogram
+	retries  metric.Int64Counter
+	errors   metric.Int64Counter
+}
+
+var _ hellosign.Metrics = Metrics{}
+
+// NewMetrics returns a Metrics using a meter from provider.
+func NewMetrics(provider metric.MeterProvider) (Metrics, error) {
+	meter := provider.Meter(ScopeName)
+	duration, err := meter.Float64Histogram("hellosign.client.duration",
+		metric.WithDescription("Duration of HelloSign API calls, including retries."),
+		metric.WithUnit("s"),
+	)
+	if err != nil {
+		return Metrics{}, err
+	}
+	retries, err := meter.Int64Counter("hellosign.client.retries",
+		metric.WithDescription("Number of times HelloSign API calls were retried."),
+		metric.WithUnit("{retry}"),
+	)
+	if err != nil {
+		return Metrics{}, err
+	}
+	errors, err := meter.Int64Counter("hellosign.client.errors",
+		metric.WithDescription("Number of HelloSign API calls that failed, after retries."),
+		metric.WithUnit("{call}"),
+	)
+	if err != nil {
+		return Metrics{}, err
+	}
+	return Metrics{duration: duration, retries: retries, errors: errors}, nil
+}
+
+// RecordCall implements hellosign.Metrics.
+func (m Metrics) RecordCall(ctx context.Context, call hellosign.Call, result hellosign.CallResult) {
+	attrs := []attribute.KeyValue{MethodKey.String(call.Method), EndpointKey.String(call.Endpoint)}
+	if result.StatusCode != 0 {
+		attrs = append(attrs, StatusKey.Int(result.StatusCode))
+	}
+	switch {
+	case result.ErrorName != "":
+		attrs = append(attrs, ErrorNameKey.String(result.ErrorName))
+	case result.Err != nil && result.StatusCode == 0:
+		attrs = append(attrs, attribute.String("error.type", "transport"))
+	}
+
+	set := metric.WithAttributes(attrs...)
+	m.duration.Record(ctx, result.Duration.Seconds(), set)
+	if result.Retries > 0 {
+		m.retries.Add(ctx, int64(result.Retries), set)
+	}
+	if result.Err != nil {
+		m.errors.Add(ctx, 1, set)
+	}
+}
@@ -0,0 +1,128 @@
+package otelhellosign
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	hellosign "github.com/jheth/hellosign-go-sdk"
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func instrumentedClient(t *testing.T) (*hellosign.Client, *tracetest.SpanRecorder, *sdkmetric.ManualReader) {
+	spans := tracetest.NewSpanRecorder()
+	reader := sdkmetric.NewManualReader()
+
+	metrics, err := NewMetrics(sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	client := &hellosign.Client{
+		APIKey:  "secret-key",
+		DryRun:  hellosign.NewDryRun(""),
+		Tracer:  NewTracer(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(spans))),
+		Metrics: metrics,
+	}
+	return client, spans, reader
+}
+
+func TestTracer(t *testing.T) {
+	assert := assert.New(t)
+
+	client, spans, _ := instrumentedClient(t)
+	res, err := client.CreateSignatureRequest(hellosign.CreationRequest{
+		TestMode: true,
+		FileURL:  []string{"https://example.com/offer_letter.pdf"},
+		Signers:  []hellosign.Signer{{Name: "Jane Doe", Email: "jane@example.com"}},
+	})
+	assert.Nil(err, "Should not return error")
+	_, err = client.GetSignatureRequest("missing")
+	assert.NotNil(err)
+
+	ended := spans.Ended()
+	if !assert.Len(ended, 2) {
+		return
+	}
+
+	create := ended[0]
+	assert.Equal("hellosign signature_request/send", create.Name())
+	assert.Equal(trace.SpanKindClient, create.SpanKind())
+	assert.Equal(codes.Unset, create.Status().Code)
+	assert.Contains(create.Attributes(), MethodKey.String("POST"))
+	assert.Contains(create.Attributes(), StatusKey.Int(200))
+	assert.Contains(create.Attributes(), RetriesKey.Int(0))
+	assert.NotEmpty(res.SignatureRequestID)
+
+	get := ended[1]
+	assert.Equal("hellosign signature_request/{id}", get.Name())
+	assert.Equal(codes.Error, get.Status().Code)
+	assert.Contains(get.Attributes(), StatusKey.Int(404))
+	assert.Contains(get.Attributes(), ErrorNameKey.String("not_found"))
+	assert.Len(get.Events(), 1, "the error is recorded")
+}
+
+func TestMetrics(t *testing.T) {
+	assert := assert.New(t)
+
+	client, _, reader := instrumentedClient(t)
+	client.GetSignatureRequest("missing")
+	client.GetSignatureRequest("missing")
+
+	failed := errors.New("connection refused")
+	client.Middleware = []hellosign.Middleware{func(http.RoundTripper) http.RoundTripper {
+		return hellosign.RoundTripFunc(func(*http.Request) (*http.Response, error) {
+			return nil, failed
+		})
+	}}
+	client.GetSignatureRequest("abc")
+
+	var data metricdata.ResourceMetrics
+	if !assert.Nil(reader.Collect(context.Background(), &data)) {
+		return
+	}
+	if !assert.Len(data.ScopeMetrics, 1) {
+		return
+	}
+	assert.Equal(ScopeName, data.ScopeMetrics[0].Scope.Name)
+
+	counts := map[attribute.Distinct]uint64{}
+	errorCounts := map[attribute.Distinct]int64{}
+	for _, m := range data.ScopeMetrics[0].Metrics {
+		switch m.Name {
+		case "hellosign.client.duration":
+			assert.Equal("s", m.Unit)
+			for _, point := range m.Data.(metricdata.Histogram[float64]).DataPoints {
+				counts[point.Attributes.Equivalent()] = point.Count
+			}
+		case "hellosign.client.errors":
+			for _, point := range m.Data.(metricdata.Sum[int64]).DataPoints {
+				errorCounts[point.Attributes.Equivalent()] = point.Value
+			}
+		}
+	}
+
+	notFound := attribute.NewSet(
+		MethodKey.String("GET"), EndpointKey.String("signature_request/{id}"),
+		StatusKey.Int(404), ErrorNameKey.String("not_found"),
+	)
+	transport := attribute.NewSet(
+		MethodKey.String("GET"), EndpointKey.String("signature_request/{id}"),
+		attribute.String("error.type", "transport"),
+	)
+	assert.Equal(map[attribute.Distinct]uint64{
+		notFound.Equivalent():  2,
+		transport.Equivalent(): 1,
+	}, counts)
+	assert.Equal(map[attribute.Distinct]int64{
+		notFound.Equivalent():  2,
+		transport.Equivalent(): 1,
+	}, errorCounts)
+}
@@ -0,0 +1,126 @@
+package hellosign
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Policy guards what a client may send, so a forgotten TestMode outside
+// production cannot create a legally binding request. Checks run before the
+// request is encoded; a refused request never reaches the network.
+//
+//	client := hellosign.Client{APIKey: key, Policy: &hellosign.Policy{
+//		ForceTestMode:       true,
+//		AllowedEmailDomains: []string{"example.com"},
+//	}}
+type Policy struct {
+	// ForceTestMode sets TestMode on every created request.
+	ForceTestMode bool
+	// AllowLive permits requests without TestMode. When false, they are refused
+	// unless ForceTestMode turns them into test requests.
+	AllowLive bool
+	// AllowedEmailDomains, when not empty, restricts signer and CC addresses
+	// to these domains and their subdomains.
+	AllowedEmailDomains []string
+}
+
+// PolicyError lists why a request was refused by the client's Policy.
+type PolicyError struct {
+	Errors []error
+}
+
+func (e *PolicyError) Error() string {
+	messages := make([]string, 0, len(e.Errors))
+	for _, err := range e.Errors {
+		messages = append(messages, err.Error())
+	}
+	return "refused by policy: " + strings.Join(messages, "; ")
+}
+
+func (e *PolicyError) add(format string, args ...interface{}) {
+	e.Errors = append(e.Errors, fmt.Errorf(format, args...))
+}
+
+func (e *PolicyError) orNil() error {
+	if len(e.Errors) > 0 {
+		return e
+	}
+	return nil
+}
+
+// WithPolicy - Enforces policy on every request created or updated by the client.
+func (m *Client) WithPolicy(policy Policy) *Client {
+	m.Policy = &policy
+
+	return m
+}
+
+// apply returns the request with test mode forced as configured, or a
+// *PolicyError when it may not be sent.
+func (p *Policy) apply(request CreationRequest) (CreationRequest, error) {
+	if p == nil {
+		return request, nil
+	}
+	e := &PolicyError{}
+
+	if p.ForceTestMode {
+		request.TestMode = true
+	}
+	p.checkTestMode(e, request.TestMode)
+
+	for i, signer := range request.Signers {
+		name := fmt.Sprintf("signers[%d]", i)
+		for j, member := range signer.GroupMembers {
+			p.checkEmail(e, fmt.Sprintf("%s[%d].email_address", name, j), member.Email)
+		}
+		if len(signer.GroupMembers) == 0 {
+			p.checkEmail(e, name+".email_address", signer.Email)
+		}
+	}
+	for i, email := range request.CCEmailAddresses {
+		p.checkEmail(e, fmt.Sprintf("cc_email_addresses[%d]", i), email)
+	}
+
+	return request, e.orNil()
+}
+
+func (p *Policy) checkTestMode(e *PolicyError, testMode bool) {
+	if !testMode && !p.AllowLive {
+		e.add("test_mode: live requests are not allowed")
+	}
+}
+
+func (p *Policy) checkEmail(e *PolicyError, name, email string) {
+	if len(p.AllowedEmailDomains) == 0 {
+		return
+	}
+
+	domain := strings.ToLower(email[strings.LastIndex(email, "@")+1:])
+	for _, allowed := range p.AllowedEmailDomains {
+		allowed = strings.ToLower(strings.TrimPrefix(allowed, "@"))
+		if domain == allowed || strings.HasSuffix(domain, "."+allowed) {
+			return
+		}
+	}
+	e.add("%s: domain of %q is not allowed", name, email)
+}
+
+// checkSend applies the test mode rule to SendSignatureRequest.
+func (p *Policy) checkSend(request SignatureRequest) error {
+	if p == nil || p.ForceTestMode {
+		return nil
+	}
+	e := &PolicyError{}
+	p.checkTestMode(e, request.TestMode)
+	return e.orNil()
+}
+
+// checkEmailUpdate applies the domain rule to UpdateSignatureRequest.
+func (p *Policy) checkEmailUpdate(email string) error {
+	if p == nil {
+		return nil
+	}
+	e := &PolicyError{}
+	p.checkEmail(e, "email_address", email)
+	return e.orNil()
+}
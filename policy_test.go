@@ -0,0 +1,78 @@
+package hellosign
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func policyRequest() CreationRequest {
+	return CreationRequest{
+		FileURL: []string{"https://example.com/offer_letter.pdf"},
+		Signers: []Signer{
+			{Name: "Jane Doe", Email: "jane@example.com"},
+			{Name: "John Doe", Email: "john@mail.Example.com", Order: 1},
+		},
+	}
+}
+
+func TestPolicyForcesTestMode(t *testing.T) {
+	assert := assert.New(t)
+
+	dryRun := NewDryRun("")
+	client := Client{APIKey: "secret-key", DryRun: dryRun}
+	client.WithPolicy(Policy{ForceTestMode: true, AllowedEmailDomains: []string{"example.com"}})
+
+	res, err := client.CreateSignatureRequest(policyRequest())
+	assert.Nil(err)
+	assert.True(res.TestMode)
+	assert.Contains(string(dryRun.Requests()[0].Body), `"test_mode":true`)
+}
+
+func TestPolicyRefusesBeforeSending(t *testing.T) {
+	assert := assert.New(t)
+
+	client := Client{
+		APIKey:     "secret-key",
+		HTTPClient: &http.Client{Transport: failingTransport{}},
+		Policy:     &Policy{AllowedEmailDomains: []string{"@example.com"}},
+	}
+
+	request := policyRequest()
+	request.Signers = append(request.Signers, Signer{
+		Group: "Managers",
+		GroupMembers: []Signer{
+			{Name: "Ann", Email: "ann@example.com"},
+			{Name: "Bob", Email: "bob@gmail.com"},
+		},
+	})
+	request.CCEmailAddresses = []string{"hr@notexample.com"}
+
+	_, err := client.CreateEmbeddedSignatureRequest(request)
+	var policyErr *PolicyError
+	assert.True(errors.As(err, &policyErr))
+	assert.Equal([]error{
+		errors.New("test_mode: live requests are not allowed"),
+		errors.New(`signers[2][1].email_address: domain of "bob@gmail.com" is not allowed`),
+		errors.New(`cc_email_addresses[0]: domain of "hr@notexample.com" is not allowed`),
+	}, policyErr.Errors)
+	assert.Contains(err.Error(), "refused by policy: test_mode")
+
+	_, err = client.UpdateSignatureRequest("id", "signature", "jane@gmail.com")
+	assert.IsType(&PolicyError{}, err)
+
+	_, err = client.SendSignatureRequest(SignatureRequest{})
+	assert.IsType(&PolicyError{}, err)
+}
+
+func TestPolicyAllowLive(t *testing.T) {
+	assert := assert.New(t)
+
+	client := Client{APIKey: "secret-key", DryRun: NewDryRun(""), Policy: &Policy{AllowLive: true}}
+
+	res, err := client.CreateSignatureRequest(policyRequest())
+	assert.Nil(err)
+	assert.False(res.TestMode)
+}
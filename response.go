@@ -0,0 +1,175 @@
+package hellosign
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// responseTag maps a struct field to a form field's api_id or name in DecodeResponses.
+const responseTag = "hellosign"
+
+// FieldValue is the value of a filled-in form field. HelloSign returns strings
+// for text fields, booleans for checkboxes and radios, and null when unset.
+type FieldValue struct {
+	value interface{}
+}
+
+// NewFieldValue wraps a string, bool, number or nil.
+func NewFieldValue(value interface{}) FieldValue {
+	return FieldValue{value: value}
+}
+
+// UnmarshalJSON accepts any JSON scalar.
+func (v *FieldValue) UnmarshalJSON(data []byte) error {
+	return json.Unmarshal(data, &v.value)
+}
+
+// MarshalJSON encodes the underlying value.
+func (v FieldValue) MarshalJSON() ([]byte, error) {
+	return json.Marshal(v.value)
+}
+
+// Interface returns the decoded value: string, bool, float64 or nil.
+func (v FieldValue) Interface() interface{} {
+	return v.value
+}
+
+// IsNull reports whether the field was left empty.
+func (v FieldValue) IsNull() bool {
+	return v.value == nil
+}
+
+// String formats the value as text. Null values are empty.
+func (v FieldValue) String() string {
+	switch value := v.value.(type) {
+	case nil:
+		return ""
+	case string:
+		return value
+	case float64:
+		return strconv.FormatFloat(value, 'f', -1, 64)
+	default:
+		return fmt.Sprint(value)
+	}
+}
+
+// Bool returns the value of a checkbox or radio field. Strings such as "1"
+// and "true" are accepted; ok is false when the value is not boolean.
+func (v FieldValue) Bool() (value bool, ok bool) {
+	switch value := v.value.(type) {
+	case bool:
+		return value, true
+	case float64:
+		return value != 0, true
+	case string:
+		b, err := strconv.ParseBool(strings.TrimSpace(value))
+		return b, err == nil
+	}
+	return false, false
+}
+
+// Float returns the value of a numeric field.
+func (v FieldValue) Float() (float64, bool) {
+	switch value := v.value.(type) {
+	case float64:
+		return value, true
+	case string:
+		f, err := strconv.ParseFloat(strings.TrimSpace(value), 64)
+		return f, err == nil
+	}
+	return 0, false
+}
+
+// ResponseByID - Returns the response for the form field with the given api_id or name, or nil.
+func (s *SignatureRequest) ResponseByID(id string) *ResponseData {
+	for _, data := range s.ResponseData {
+		if data.ApiID == id {
+			return data
+		}
+	}
+	for _, data := range s.ResponseData {
+		if data.Name == id {
+			return data
+		}
+	}
+	return nil
+}
+
+// DecodeResponses - Copies filled-in form field values onto the struct pointed to by dst.
+// Fields are matched by their `hellosign:"api_id"` tag, falling back to the form field name.
+// Fields without a response, or with a null value, are left untouched.
+func (s *SignatureRequest) DecodeResponses(dst interface{}) error {
+	val := reflect.ValueOf(dst)
+	if val.Kind() != reflect.Ptr || val.IsNil() || val.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("hellosign: DecodeResponses requires a non-nil struct pointer")
+	}
+	val = val.Elem()
+	structType := val.Type()
+
+	for i := 0; i < val.NumField(); i++ {
+		field := structType.Field(i)
+		id := field.Tag.Get(responseTag)
+		if field.PkgPath != "" || id == "" || id == "-" {
+			continue
+		}
+
+		data := s.ResponseByID(id)
+		if data == nil || data.Value.IsNull() {
+			continue
+		}
+
+		if err := setFieldValue(val.Field(i), data.Value); err != nil {
+			return fmt.Errorf("response %s: %v", id, err)
+		}
+	}
+	return nil
+}
+
+var fieldValueType = reflect.TypeOf(FieldValue{})
+
+func setFieldValue(field reflect.Value, value FieldValue) error {
+	if field.Type() == fieldValueType {
+		field.Set(reflect.ValueOf(value))
+		return nil
+	}
+
+	if field.Kind() == reflect.Ptr {
+		ptr := reflect.New(field.Type().Elem())
+		if err := setFieldValue(ptr.Elem(), value); err != nil {
+			return err
+		}
+		field.Set(ptr)
+		return nil
+	}
+
+	switch field.Kind() {
+	case reflect.String:
+		field.SetString(value.String())
+	case reflect.Bool:
+		b, ok := value.Bool()
+		if !ok {
+			return fmt.Errorf("cannot use %q as bool", value.String())
+		}
+		field.SetBool(b)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		f, ok := value.Float()
+		if !ok || f != float64(int64(f)) {
+			return fmt.Errorf("cannot use %q as integer", value.String())
+		}
+		field.SetInt(int64(f))
+	case reflect.Float32, reflect.Float64:
+		f, ok := value.Float()
+		if !ok {
+			return fmt.Errorf("cannot use %q as number", value.String())
+		}
+		field.SetFloat(f)
+	case reflect.Interface:
+		field.Set(reflect.ValueOf(value.Interface()))
+	default:
+		return fmt.Errorf("unsupported field type %s", field.Type())
+	}
+	return nil
+}
@@ -0,0 +1,77 @@
+package hellosign
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const completedResponseData = `{"response_data":[
+	{"api_id":"full_name","name":"Full Name","type":"text","value":"Jane Doe","signature_id":"5a25"},
+	{"api_id":"agree","name":"Agree","type":"checkbox","value":true,"signature_id":"5a25"},
+	{"api_id":"plan_gold","name":"Gold","type":"radio","value":false,"signature_id":"5a25"},
+	{"api_id":"seats","name":"Seats","type":"text","value":"12","signature_id":"5a25"},
+	{"api_id":"018336_1","name":"Notes","type":"text","value":null,"signature_id":"5a25"},
+	{"api_id":"state","name":"State","type":"dropdown","value":"CA","signature_id":"5a25"}
+]}`
+
+type onboardingForm struct {
+	FullName string     `hellosign:"full_name"`
+	Agree    bool       `hellosign:"agree"`
+	Gold     *bool      `hellosign:"plan_gold"`
+	Seats    int        `hellosign:"seats"`
+	Notes    string     `hellosign:"Notes"`
+	State    FieldValue `hellosign:"State"`
+	Ignored  string
+}
+
+func TestResponseDataValueTypes(t *testing.T) {
+	assert := assert.New(t)
+
+	req := &SignatureRequest{}
+	assert.Nil(json.Unmarshal([]byte(completedResponseData), req))
+
+	assert.Equal("Jane Doe", req.ResponseData[0].Value.String())
+
+	checked, ok := req.ResponseData[1].Value.Bool()
+	assert.True(ok)
+	assert.True(checked)
+
+	assert.True(req.ResponseData[4].Value.IsNull())
+	assert.Equal("", req.ResponseData[4].Value.String())
+
+	_, ok = req.ResponseData[0].Value.Bool()
+	assert.False(ok)
+
+	data, err := json.Marshal(req.ResponseData[1])
+	assert.Nil(err, "Should not return error")
+	assert.Contains(string(data), `"value":true`)
+}
+
+func TestSignatureRequestDecodeResponses(t *testing.T) {
+	assert := assert.New(t)
+
+	req := &SignatureRequest{}
+	assert.Nil(json.Unmarshal([]byte(completedResponseData), req))
+
+	form := onboardingForm{Notes: "unchanged", Ignored: "kept"}
+	assert.Nil(req.DecodeResponses(&form))
+
+	assert.Equal("Jane Doe", form.FullName)
+	assert.True(form.Agree)
+	if assert.NotNil(form.Gold) {
+		assert.False(*form.Gold)
+	}
+	assert.Equal(12, form.Seats)
+	assert.Equal("unchanged", form.Notes)
+	assert.Equal("CA", form.State.String())
+	assert.Equal("kept", form.Ignored)
+
+	var invalid struct {
+		Name int `hellosign:"full_name"`
+	}
+	err := req.DecodeResponses(&invalid)
+	assert.Equal(`response full_name: cannot use "Jane Doe" as integer`, err.Error())
+	assert.NotNil(req.DecodeResponses(form))
+}
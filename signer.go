@@ -0,0 +1,112 @@
+package hellosign
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+)
+
+// Values for Signer.SMSPhoneNumberType.
+const (
+	SMSPhoneNumberTypeAuthentication = "authentication"
+	SMSPhoneNumberTypeDelivery       = "delivery"
+)
+
+// Signature types for SigningOptions.DefaultType.
+const (
+	SigningTypeDraw   = "draw"
+	SigningTypeType   = "type"
+	SigningTypeUpload = "upload"
+	SigningTypePhone  = "phone"
+)
+
+// SigningOptions controls which signature methods signers may use.
+type SigningOptions struct {
+	Draw        bool   `json:"draw"`         // Allow drawing the signature.
+	Type        bool   `json:"type"`         // Allow typing the signature.
+	Upload      bool   `json:"upload"`       // Allow uploading the signature.
+	Phone       bool   `json:"phone"`        // Allow signing from a phone.
+	DefaultType string `json:"default_type"` // The signature method shown first. See the SigningType* constants.
+}
+
+// FieldOptions sets request-wide options for form fields.
+type FieldOptions struct {
+	DateFormat string `json:"date_format"` // Format of date_signed fields. See the DateFormat* constants.
+}
+
+func (s Signer) marshalForm(e *formEncoder, name string) error {
+	if len(s.GroupMembers) > 0 {
+		if s.Group == "" {
+			return fmt.Errorf("%s: group name is required for group signers", name)
+		}
+		if err := e.WriteField(name+"[group]", s.Group); err != nil {
+			return err
+		}
+		if s.Order != 0 {
+			if err := e.WriteField(name+"[order]", strconv.Itoa(s.Order)); err != nil {
+				return err
+			}
+		}
+		for j, member := range s.GroupMembers {
+			if len(member.GroupMembers) > 0 {
+				return fmt.Errorf("%s[%v]: signer groups cannot be nested", name, j)
+			}
+			member.Order = 0
+			if err := member.marshalForm(e, fmt.Sprintf("%s[%v]", name, j)); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	if err := s.validateOptions(name); err != nil {
+		return err
+	}
+	return e.encodeStruct(name, reflect.ValueOf(s))
+}
+
+// marshalBody mirrors marshalForm. A group is encoded as a grouped signer
+// object, {"group": ..., "order": ..., "signers": [...]}, with its members
+// in a JSON array.
+func (s Signer) marshalBody(e *jsonEncoder, name string) (interface{}, error) {
+	if len(s.GroupMembers) > 0 {
+		if s.Group == "" {
+			return nil, fmt.Errorf("%s: group name is required for group signers", name)
+		}
+		group := map[string]interface{}{"group": s.Group}
+		if s.Order != 0 {
+			group["order"] = s.Order
+		}
+		members := make([]interface{}, 0, len(s.GroupMembers))
+		for j, member := range s.GroupMembers {
+			if len(member.GroupMembers) > 0 {
+				return nil, fmt.Errorf("%s[%v]: signer groups cannot be nested", name, j)
+			}
+			member.Order = 0
+			body, err := member.marshalBody(e, fmt.Sprintf("%s[%v]", name, j))
+			if err != nil {
+				return nil, err
+			}
+			members = append(members, body)
+		}
+		group["signers"] = members
+		return group, nil
+	}
+
+	if err := s.validateOptions(name); err != nil {
+		return nil, err
+	}
+	return e.encodeStruct(name, reflect.ValueOf(s))
+}
+
+func (s Signer) validateOptions(name string) error {
+	switch s.SMSPhoneNumberType {
+	case "", SMSPhoneNumberTypeAuthentication, SMSPhoneNumberTypeDelivery:
+	default:
+		return fmt.Errorf("%s: unknown sms_phone_number_type %q", name, s.SMSPhoneNumberType)
+	}
+	if s.SMSPhoneNumberType != "" && s.SMSPhoneNumber == "" {
+		return fmt.Errorf("%s: sms_phone_number_type requires sms_phone_number", name)
+	}
+	return nil
+}
@@ -0,0 +1,67 @@
+package hellosign
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSignerOptionsEncoded(t *testing.T) {
+	assert := assert.New(t)
+
+	request := CreationRequest{
+		FileURL: []string{"http://www.pdf995.com/samples/pdf.pdf"},
+		Signers: []Signer{
+			{
+				Email:              "jane@example.com",
+				Name:               "Jane Doe",
+				SMSPhoneNumber:     "+14155550100",
+				SMSPhoneNumberType: SMSPhoneNumberTypeAuthentication,
+			},
+			{
+				Group: "Legal",
+				Order: 1,
+				GroupMembers: []Signer{
+					{Email: "a@example.com", Name: "Alice"},
+					{Email: "b@example.com", Name: "Bob"},
+				},
+			},
+		},
+		SigningOptions: &SigningOptions{Draw: true, Type: true, DefaultType: SigningTypeDraw},
+		FieldOptions:   &FieldOptions{DateFormat: DateFormatDayMonthYearSlash},
+	}
+
+	object, form := encodeRequest(t, request)
+
+	assert.Equal("+14155550100", form.Value["signers[0][sms_phone_number]"][0])
+	assert.Equal("authentication", form.Value["signers[0][sms_phone_number_type]"][0])
+	assert.Equal("Legal", form.Value["signers[1][group]"][0])
+	assert.Equal("1", form.Value["signers[1][order]"][0])
+	assert.Equal("a@example.com", form.Value["signers[1][0][email_address]"][0])
+	assert.Equal("Bob", form.Value["signers[1][1][name]"][0])
+	assert.Nil(form.Value["signers[1][email_address]"])
+	assert.Equal(`{"draw":true,"type":true,"upload":false,"phone":false,"default_type":"draw"}`, form.Value["signing_options"][0])
+	assert.Equal(`{"date_format":"DD / MM / YYYY"}`, form.Value["field_options"][0])
+
+	assert.Equal(`[{"email_address":"jane@example.com","name":"Jane Doe","sms_phone_number":"+14155550100","sms_phone_number_type":"authentication"},`+
+		`{"group":"Legal","order":1,"signers":[{"email_address":"a@example.com","name":"Alice"},{"email_address":"b@example.com","name":"Bob"}]}]`,
+		jsonValue(object["signers"]))
+	assert.Equal(`{"default_type":"draw","draw":true,"phone":false,"type":true,"upload":false}`, jsonValue(object["signing_options"]))
+	assert.Equal(`{"date_format":"DD / MM / YYYY"}`, jsonValue(object["field_options"]))
+}
+
+func TestSignerOptionsInvalid(t *testing.T) {
+	assert := assert.New(t)
+	client := Client{}
+
+	request := CreationRequest{
+		FileURL: []string{"http://www.pdf995.com/samples/pdf.pdf"},
+		Signers: []Signer{{Email: "jane@example.com", Name: "Jane Doe", SMSPhoneNumberType: "voice"}},
+	}
+	_, _, err := client.marshalRequest(request)
+	assert.Equal(`invalid request: signers[0]: unknown sms_phone_number_type "voice"`, err.Error())
+
+	request.Signers = []Signer{{GroupMembers: []Signer{{Email: "a@example.com", Name: "Alice"}}}}
+	_, _, err = client.marshalRequest(request)
+	assert.Equal("invalid request: signers[0]: group name is required for group signers", err.Error())
+}
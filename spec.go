@@ -0,0 +1,374 @@
+package hellosign
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"reflect"
+	"strings"
+	"text/template"
+
+	"gopkg.in/yaml.v3"
+)
+
+// spec is the file format read by LoadSpec. JSON documents use the same keys.
+//
+//	test_mode: true
+//	title: Offer letter for {{ .Name }}
+//	files: [offer_letter.pdf]
+//	signers:
+//	  - name: "{{ .Name }}"
+//	    email: "{{ .Email }}"
+//	    pin: "1234"
+//	cc: [hr@example.com]
+//	form_fields:
+//	  - - {api_id: salary, type: text, x: 100, y: 200, width: 120, height: 16, signer: 0, page: 1}
+//	attachments:
+//	  - {name: ID, signer_index: 0, required: true}
+//	metadata:
+//	  employee_id: "{{ .EmployeeID }}"
+type spec struct {
+	TestMode           bool              `yaml:"test_mode"`
+	ClientID           string            `yaml:"client_id"`
+	Title              string            `yaml:"title"`
+	Subject            string            `yaml:"subject"`
+	Message            string            `yaml:"message"`
+	SigningRedirectURL string            `yaml:"signing_redirect_url"`
+	AllowDecline       bool              `yaml:"allow_decline"`
+	UseTextTags        bool              `yaml:"use_text_tags"`
+	HideTextTags       bool              `yaml:"hide_text_tags"`
+	Files              []string          `yaml:"files"`
+	FileURLs           []string          `yaml:"file_urls"`
+	Signers            []specSigner      `yaml:"signers"`
+	CC                 []string          `yaml:"cc"`
+	FormFields         [][]yaml.Node     `yaml:"form_fields"`
+	Attachments        []specAttachment  `yaml:"attachments"`
+	Metadata           map[string]string `yaml:"metadata"`
+}
+
+type specSigner struct {
+	Name           string `yaml:"name"`
+	Email          string `yaml:"email"`
+	Order          int    `yaml:"order"`
+	Pin            string `yaml:"pin"`
+	SMSPhoneNumber string `yaml:"sms_phone_number"`
+}
+
+type specAttachment struct {
+	Name         string `yaml:"name"`
+	Instructions string `yaml:"instructions"`
+	SignerIndex  int    `yaml:"signer_index"`
+	Required     bool   `yaml:"required"`
+}
+
+// specKeys maps spec keys to the request parameters named in validation errors.
+var specKeys = map[string]string{
+	"files":       "file",
+	"file_urls":   "file_url",
+	"cc":          "cc_email_addresses",
+	"form_fields": "form_fields_per_document",
+}
+
+// SpecError is a problem found at a line of a spec. Line is zero when the
+// problem concerns the spec as a whole.
+type SpecError struct {
+	Line int
+	Err  error
+}
+
+func (e *SpecError) Error() string {
+	if e.Line == 0 {
+		return e.Err.Error()
+	}
+	return fmt.Sprintf("line %d: %v", e.Line, e.Err)
+}
+
+func (e *SpecError) Unwrap() error {
+	return e.Err
+}
+
+// LoadSpec - Reads a YAML or JSON signature request spec into a CreationRequest.
+// Each string value of the spec is executed as a text/template with vars as its
+// data, so {{ .Name }} is replaced by the Name field or key of vars; referencing
+// a missing key is an error. Values are substituted after the spec is parsed,
+// so they can't change its structure: a value that reads as a bool or a
+// number, such as test_mode: "{{ .Test }}", fills bool and int fields, and
+// any other value is a string. The resulting
+// request is validated, and problems are returned as a *ValidationError of
+// *SpecError values carrying line numbers.
+func LoadSpec(r io.Reader, vars interface{}) (CreationRequest, error) {
+	data, err := ioutil.ReadAll(r)
+	if err != nil {
+		return CreationRequest{}, err
+	}
+
+	root := yaml.Node{}
+	if err := yaml.Unmarshal(data, &root); err != nil {
+		return CreationRequest{}, err
+	}
+	if len(root.Content) == 0 {
+		root.Content = []*yaml.Node{{Kind: yaml.MappingNode, Tag: "!!map"}}
+	}
+	document := root.Content[0]
+
+	v := &ValidationError{}
+	expand(document, vars, v)
+	checkKeys(document, reflect.TypeOf(spec{}), v)
+	if len(v.Errors) > 0 {
+		return CreationRequest{}, v
+	}
+
+	lines := map[string]int{}
+	indexLines(lines, "", document)
+
+	s := spec{}
+	if err := document.Decode(&s); err != nil {
+		if typeErr, ok := err.(*yaml.TypeError); ok {
+			for _, message := range typeErr.Errors {
+				v.Errors = append(v.Errors, specError(message))
+			}
+			return CreationRequest{}, v
+		}
+		return CreationRequest{}, err
+	}
+
+	request, v := s.creationRequest()
+	if err := request.Validate(); err != nil {
+		for _, err := range err.(*ValidationError).Errors {
+			v.Errors = append(v.Errors, locate(lines, err))
+		}
+	}
+	if len(v.Errors) > 0 {
+		return request, v
+	}
+	return request, nil
+}
+
+// expand executes the templates in the scalar values under node. Keys are
+// left alone.
+func expand(node *yaml.Node, vars interface{}, v *ValidationError) {
+	switch node.Kind {
+	case yaml.MappingNode:
+		for i := 1; i < len(node.Content); i += 2 {
+			expand(node.Content[i], vars, v)
+		}
+	case yaml.SequenceNode:
+		for _, item := range node.Content {
+			expand(item, vars, v)
+		}
+	case yaml.ScalarNode:
+		if !strings.Contains(node.Value, "{{") {
+			return
+		}
+		tmpl, err := template.New("spec").Option("missingkey=error").Parse(node.Value)
+		if err != nil {
+			v.Errors = append(v.Errors, &SpecError{Line: node.Line, Err: err})
+			return
+		}
+		var buf bytes.Buffer
+		if err := tmpl.Execute(&buf, vars); err != nil {
+			v.Errors = append(v.Errors, &SpecError{Line: node.Line, Err: err})
+			return
+		}
+		node.Value = buf.String()
+		// Resolve the expanded value as if it had been written unquoted, so
+		// "{{ .Test }}" can fill a bool and "{{ .N }}" an int. Anything else,
+		// null included, stays a string.
+		node.Tag = "!!str"
+		resolved := (&yaml.Node{Kind: yaml.ScalarNode, Value: node.Value}).ShortTag()
+		switch resolved {
+		case "!!bool", "!!int", "!!float":
+			node.Tag = resolved
+		}
+	}
+}
+
+// checkKeys reports mapping keys without a field in t, as yaml's KnownFields
+// would; decoding a node has no such option.
+func checkKeys(node *yaml.Node, t reflect.Type, v *ValidationError) {
+	for t.Kind() == reflect.Ptr || t.Kind() == reflect.Slice {
+		t = t.Elem()
+	}
+	if t == reflect.TypeOf(yaml.Node{}) {
+		return
+	}
+
+	switch node.Kind {
+	case yaml.SequenceNode:
+		for _, item := range node.Content {
+			checkKeys(item, t, v)
+		}
+	case yaml.MappingNode:
+		if t.Kind() != reflect.Struct {
+			return
+		}
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			key := node.Content[i]
+			field, ok := yamlField(t, key.Value)
+			if !ok {
+				v.Errors = append(v.Errors, &SpecError{
+					Line: key.Line,
+					Err:  fmt.Errorf("field %s not found in type %s", key.Value, t),
+				})
+				continue
+			}
+			checkKeys(node.Content[i+1], field.Type, v)
+		}
+	}
+}
+
+func yamlField(t reflect.Type, name string) (reflect.StructField, bool) {
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if strings.Split(field.Tag.Get("yaml"), ",")[0] == name {
+			return field, true
+		}
+	}
+	return reflect.StructField{}, false
+}
+
+func (s spec) creationRequest() (CreationRequest, *ValidationError) {
+	v := &ValidationError{}
+	request := CreationRequest{
+		TestMode:           s.TestMode,
+		ClientID:           s.ClientID,
+		Title:              s.Title,
+		Subject:            s.Subject,
+		Message:            s.Message,
+		SigningRedirectURL: s.SigningRedirectURL,
+		AllowDecline:       s.AllowDecline,
+		UseTextTags:        s.UseTextTags,
+		HideTextTags:       s.HideTextTags,
+		File:               s.Files,
+		FileURL:            s.FileURLs,
+		CCEmailAddresses:   s.CC,
+		Metadata:           s.Metadata,
+	}
+
+	for _, signer := range s.Signers {
+		request.Signers = append(request.Signers, Signer{
+			Name:           signer.Name,
+			Email:          signer.Email,
+			Order:          signer.Order,
+			Pin:            signer.Pin,
+			SMSPhoneNumber: signer.SMSPhoneNumber,
+		})
+	}
+
+	for _, attachment := range s.Attachments {
+		request.Attachments = append(request.Attachments, Attachment(attachment))
+	}
+
+	for i, nodes := range s.FormFields {
+		fields := []DocumentFormField{}
+		for j, node := range nodes {
+			field, err := decodeFormField(&node)
+			if err != nil {
+				v.Errors = append(v.Errors, &SpecError{
+					Line: node.Line,
+					Err:  fmt.Errorf("form_fields[%d][%d]: %v", i, j, err),
+				})
+				continue
+			}
+			fields = append(fields, field)
+		}
+		request.FormFieldsPerDocument = append(request.FormFieldsPerDocument, fields)
+	}
+
+	return request, v
+}
+
+// decodeFormField reuses the JSON names of DocumentFormField, so form fields
+// in a spec are written exactly as HelloSign documents them.
+func decodeFormField(node *yaml.Node) (DocumentFormField, error) {
+	field := DocumentFormField{}
+
+	var value map[string]interface{}
+	if err := node.Decode(&value); err != nil {
+		return field, err
+	}
+	data, err := json.Marshal(value)
+	if err != nil {
+		return field, err
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+	err = decoder.Decode(&field)
+	return field, err
+}
+
+// indexLines records the line of every value under node, keyed by the request
+// parameter path used in validation errors, e.g. signers[1] or metadata[id].
+func indexLines(lines map[string]int, path string, node *yaml.Node) {
+	switch node.Kind {
+	case yaml.MappingNode:
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			key, value := node.Content[i], node.Content[i+1]
+			name := key.Value
+			switch {
+			case path == "":
+				if renamed, ok := specKeys[name]; ok {
+					name = renamed
+				}
+			case path == "metadata":
+				name = path + "[" + name + "]"
+			default:
+				name = path + "." + name
+			}
+			lines[name] = key.Line
+			indexLines(lines, name, value)
+		}
+	case yaml.SequenceNode:
+		for i, item := range node.Content {
+			name := fmt.Sprintf("%s[%d]", path, i)
+			lines[name] = item.Line
+			indexLines(lines, name, item)
+		}
+	}
+}
+
+// locate attaches the spec line to a validation error of the form "path: message",
+// and names the parameter as it is spelled in the spec.
+func locate(lines map[string]int, err error) error {
+	message := err.Error()
+	i := strings.Index(message, ": ")
+	if i < 0 {
+		return &SpecError{Err: err}
+	}
+
+	path := message[:i]
+	top := path
+	if j := strings.IndexAny(top, "[."); j >= 0 {
+		top = top[:j]
+	}
+	for key, renamed := range specKeys {
+		if top == renamed {
+			message = key + message[len(renamed):]
+			break
+		}
+	}
+
+	for path != "" {
+		if line, ok := lines[path]; ok {
+			return &SpecError{Line: line, Err: fmt.Errorf("%s", message)}
+		}
+		j := strings.LastIndexAny(path, "[.")
+		if j < 0 {
+			break
+		}
+		path = path[:j]
+	}
+	return &SpecError{Err: fmt.Errorf("%s", message)}
+}
+
+// specError turns a yaml "line N: message" string into a SpecError.
+func specError(message string) error {
+	var line int
+	if n, _ := fmt.Sscanf(message, "line %d:", &line); n == 1 {
+		message = strings.TrimSpace(message[strings.Index(message, ":")+1:])
+	}
+	return &SpecError{Line: line, Err: fmt.Errorf("%s", message)}
+}
@@ -0,0 +1,167 @@
+package hellosign
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const offerSpec = `test_mode: true
+title: Offer letter for {{ .Name }}
+files: [fixtures/offer_letter.pdf]
+signers:
+  - name: "{{ .Name }}"
+    email: "{{ .Email }}"
+    pin: "1234"
+  - name: HR
+    email: hr@example.com
+    order: 5
+cc: [legal@example.com]
+form_fields:
+  - - {api_id: salary, name: Salary, type: text, x: 100, y: 200, width: 120, height: 16, signer: 0, page: 1}
+attachments:
+  - {name: ID, instructions: Passport or license, signer_index: 0, required: true}
+metadata:
+  employee_id: "{{ .EmployeeID }}"
+`
+
+func TestLoadSpec(t *testing.T) {
+	assert := assert.New(t)
+
+	vars := map[string]string{"Name": "Jane Doe", "Email": "jane@example.com", "EmployeeID": "42"}
+	request, err := LoadSpec(strings.NewReader(offerSpec), vars)
+	if !assert.Nil(err, "Should not return error") {
+		return
+	}
+
+	assert.True(request.TestMode)
+	assert.Equal("Offer letter for Jane Doe", request.Title)
+	assert.Equal([]string{"fixtures/offer_letter.pdf"}, request.File)
+	assert.Equal([]Signer{
+		{Name: "Jane Doe", Email: "jane@example.com", Pin: "1234"},
+		{Name: "HR", Email: "hr@example.com", Order: 5},
+	}, request.Signers)
+	assert.Equal([]string{"legal@example.com"}, request.CCEmailAddresses)
+	assert.Equal([][]DocumentFormField{{
+		{APIId: "salary", Name: "Salary", Type: FieldTypeText, X: 100, Y: 200, Width: 120, Height: 16, Page: 1},
+	}}, request.FormFieldsPerDocument)
+	assert.Equal([]Attachment{{Name: "ID", Instructions: "Passport or license", Required: true}}, request.Attachments)
+	assert.Equal(map[string]string{"employee_id": "42"}, request.Metadata)
+}
+
+func TestLoadSpecJSON(t *testing.T) {
+	assert := assert.New(t)
+
+	request, err := LoadSpec(strings.NewReader(`{
+  "file_urls": ["https://example.com/nda.pdf"],
+  "signers": [{"name": "Jane", "email": "jane@example.com"}]
+}`), nil)
+	assert.Nil(err, "Should not return error")
+	assert.Equal([]string{"https://example.com/nda.pdf"}, request.FileURL)
+	assert.Equal("jane@example.com", request.Signers[0].Email)
+}
+
+func TestLoadSpecErrors(t *testing.T) {
+	assert := assert.New(t)
+
+	_, err := LoadSpec(strings.NewReader(offerSpec), map[string]string{"Name": "Jane"})
+	assert.Contains(err.Error(), `map has no entry for key "Email"`)
+
+	_, err = LoadSpec(strings.NewReader("files: [a.pdf]\nsigner: []\n"), nil)
+	assert.Equal("invalid request: line 2: field signer not found in type hellosign.spec", err.Error())
+
+	_, err = LoadSpec(strings.NewReader(`files: [a.pdf]
+signers:
+  - name: Jane
+    email: jane@example.com
+  - name: John
+    email: not-an-email
+form_fields:
+  - - {api_id: a, type: text, signer: 0}
+    - {api_id: b, type: bogus, signer: 3}
+  - - {api_id: c, type: text, colour: red}
+attachments:
+  - {name: ID, signer_index: 4}
+metadata:
+  key: value
+`), nil)
+
+	v, ok := err.(*ValidationError)
+	if !assert.True(ok, "Should return a ValidationError") {
+		return
+	}
+
+	var messages []string
+	for _, err := range v.Errors {
+		messages = append(messages, err.Error())
+	}
+	assert.Equal([]string{
+		`line 10: form_fields[1][0]: json: unknown field "colour"`,
+		`line 5: signers[1]: invalid email_address "not-an-email"`,
+		`line 7: form_fields: expected 1 documents, got 2`,
+		`line 9: form_fields[0][1]: signer 3 is out of range`,
+		`line 9: form_fields[0][1]: b: unknown type "bogus"`,
+		`line 12: attachments[0]: signer_index 4 is out of range`,
+	}, messages)
+	assert.Equal(10, v.Errors[0].(*SpecError).Line)
+}
+
+func TestLoadSpecVarsAreValues(t *testing.T) {
+	assert := assert.New(t)
+
+	spec := `test_mode: true
+title: "Offer for {{ .Name }}"
+files: [a.pdf]
+signers:
+  - name: "{{ .Name }}"
+    email: "{{ .Email }}"
+  - name: HR
+    email: hr@example.com
+`
+	vars := map[string]string{"Name": "Jane\ntest_mode: false\n\"x\": y", "Email": "jane@example.com"}
+	request, err := LoadSpec(strings.NewReader(spec), vars)
+	if !assert.Nil(err, "Should not return error") {
+		return
+	}
+	assert.True(request.TestMode)
+	assert.Equal("Offer for "+vars["Name"], request.Title)
+	assert.Equal(vars["Name"], request.Signers[0].Name)
+	assert.Equal(0, request.Signers[1].Order, "signers without an order sign in parallel")
+
+	vars["Email"] = "not-an-email"
+	_, err = LoadSpec(strings.NewReader(spec), vars)
+	assert.Equal(`invalid request: line 5: signers[0]: invalid email_address "not-an-email"`, err.Error())
+
+	_, err = LoadSpec(strings.NewReader(spec), map[string]string{"Name": "Jane"})
+	if assert.NotNil(err) {
+		assert.Equal(6, err.(*ValidationError).Errors[0].(*SpecError).Line)
+	}
+}
+
+func TestLoadSpecTemplatedScalars(t *testing.T) {
+	assert := assert.New(t)
+
+	spec := `test_mode: "{{ .Test }}"
+files: [a.pdf]
+signers:
+  - name: "{{ .Name }}"
+    email: jane@example.com
+    order: "{{ .Order }}"
+    pin: "{{ .Pin }}"
+metadata:
+  employee_id: "{{ .EmployeeID }}"
+`
+	vars := map[string]interface{}{"Test": true, "Name": "null", "Order": 2, "Pin": "0123", "EmployeeID": 42}
+	request, err := LoadSpec(strings.NewReader(spec), vars)
+	if !assert.Nil(err, "Should not return error") {
+		return
+	}
+	assert.True(request.TestMode)
+	assert.Equal([]Signer{{Name: "null", Email: "jane@example.com", Order: 2, Pin: "0123"}}, request.Signers)
+	assert.Equal(map[string]string{"employee_id": "42"}, request.Metadata)
+
+	vars["Order"] = "second"
+	_, err = LoadSpec(strings.NewReader(spec), vars)
+	assert.Equal("invalid request: line 6: cannot unmarshal !!str `second` into int", err.Error())
+}
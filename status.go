@@ -0,0 +1,132 @@
+package hellosign
+
+import (
+	"sort"
+	"strings"
+)
+
+// SignatureStatus is the status_code reported for each Signature.
+type SignatureStatus string
+
+// Signature status codes returned by HelloSign.
+const (
+	StatusSuccess                SignatureStatus = "success"
+	StatusOnHold                 SignatureStatus = "on_hold"
+	StatusSigned                 SignatureStatus = "signed"
+	StatusAwaitingSignature      SignatureStatus = "awaiting_signature"
+	StatusDeclined               SignatureStatus = "declined"
+	StatusErrorUnknown           SignatureStatus = "error_unknown"
+	StatusErrorFile              SignatureStatus = "error_file"
+	StatusErrorComponentPosition SignatureStatus = "error_component_position"
+	StatusErrorTextTag           SignatureStatus = "error_text_tag"
+)
+
+// IsError reports whether the status is one of the error_* codes.
+func (s SignatureStatus) IsError() bool {
+	return strings.HasPrefix(string(s), "error")
+}
+
+// IsPending reports whether the signer still has to act.
+func (s SignatureStatus) IsPending() bool {
+	return s == StatusAwaitingSignature || s == StatusOnHold
+}
+
+// IsSigned reports whether the signer has completed their signature.
+func (s SignatureStatus) IsSigned() bool {
+	return s == StatusSigned || s == StatusSuccess
+}
+
+// RequestStatus summarises the state of a whole SignatureRequest.
+type RequestStatus string
+
+// Overall signature request states returned by SignatureRequest.OverallStatus.
+const (
+	RequestStatusAwaitingSignature RequestStatus = "awaiting_signature"
+	RequestStatusPartiallySigned   RequestStatus = "partially_signed"
+	RequestStatusOnHold            RequestStatus = "on_hold"
+	RequestStatusComplete          RequestStatus = "complete"
+	RequestStatusDeclined          RequestStatus = "declined"
+	RequestStatusError             RequestStatus = "error"
+)
+
+// OverallStatus - Combines the request flags and each signer's status into a single state.
+// Errors take precedence over declines, which take precedence over completion.
+func (s *SignatureRequest) OverallStatus() RequestStatus {
+	if s.HasError {
+		return RequestStatusError
+	}
+	for _, sig := range s.Signatures {
+		if sig.StatusCode.IsError() {
+			return RequestStatusError
+		}
+	}
+
+	if s.IsDeclined {
+		return RequestStatusDeclined
+	}
+	for _, sig := range s.Signatures {
+		if sig.StatusCode == StatusDeclined {
+			return RequestStatusDeclined
+		}
+	}
+
+	if s.IsComplete {
+		return RequestStatusComplete
+	}
+
+	signed, onHold := 0, 0
+	for _, sig := range s.Signatures {
+		switch {
+		case sig.StatusCode.IsSigned():
+			signed++
+		case sig.StatusCode == StatusOnHold:
+			onHold++
+		}
+	}
+
+	switch {
+	case len(s.Signatures) > 0 && signed == len(s.Signatures):
+		return RequestStatusComplete
+	case onHold > 0:
+		return RequestStatusOnHold
+	case signed > 0:
+		return RequestStatusPartiallySigned
+	}
+	return RequestStatusAwaitingSignature
+}
+
+// PendingSigners - Returns the signatures that have not been signed yet, in signing order.
+func (s *SignatureRequest) PendingSigners() []*Signature {
+	pending := []*Signature{}
+	for _, sig := range s.Signatures {
+		if sig.StatusCode.IsPending() {
+			pending = append(pending, sig)
+		}
+	}
+
+	sort.SliceStable(pending, func(i, j int) bool {
+		return pending[i].Order < pending[j].Order
+	})
+
+	return pending
+}
+
+// NextSigner - Returns the signer expected to sign next, or nil when nobody is waiting.
+// When the request has no signing order the first pending signer is returned.
+func (s *SignatureRequest) NextSigner() *Signature {
+	pending := s.PendingSigners()
+	if len(pending) == 0 {
+		return nil
+	}
+	return pending[0]
+}
+
+// SignerByEmail - Returns the signature for the given email address (case-insensitive), or nil.
+func (s *SignatureRequest) SignerByEmail(email string) *Signature {
+	for _, sig := range s.Signatures {
+		if strings.EqualFold(sig.SignerEmailAddress, email) {
+			return sig
+		}
+	}
+	return nil
+}
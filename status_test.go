@@ -0,0 +1,57 @@
+package hellosign
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSignatureRequestOverallStatus(t *testing.T) {
+	assert := assert.New(t)
+
+	req := &SignatureRequest{
+		Signatures: []*Signature{
+			{SignerEmailAddress: "a@example.com", StatusCode: StatusAwaitingSignature},
+			{SignerEmailAddress: "b@example.com", StatusCode: StatusAwaitingSignature},
+		},
+	}
+	assert.Equal(RequestStatusAwaitingSignature, req.OverallStatus())
+
+	req.Signatures[0].StatusCode = StatusSigned
+	assert.Equal(RequestStatusPartiallySigned, req.OverallStatus())
+
+	req.Signatures[1].StatusCode = StatusSigned
+	assert.Equal(RequestStatusComplete, req.OverallStatus())
+
+	req.Signatures[1].StatusCode = StatusDeclined
+	assert.Equal(RequestStatusDeclined, req.OverallStatus())
+
+	req.Signatures[0].StatusCode = StatusErrorFile
+	assert.Equal(RequestStatusError, req.OverallStatus())
+}
+
+func TestSignatureRequestSigners(t *testing.T) {
+	assert := assert.New(t)
+
+	req := &SignatureRequest{
+		Signatures: []*Signature{
+			{SignatureID: "3", SignerEmailAddress: "c@example.com", Order: 2, StatusCode: StatusAwaitingSignature},
+			{SignatureID: "1", SignerEmailAddress: "a@example.com", Order: 0, StatusCode: StatusSigned},
+			{SignatureID: "2", SignerEmailAddress: "B@example.com", Order: 1, StatusCode: StatusAwaitingSignature},
+		},
+	}
+
+	pending := req.PendingSigners()
+	assert.Equal(2, len(pending))
+	assert.Equal("2", pending[0].SignatureID)
+	assert.Equal("3", pending[1].SignatureID)
+
+	assert.Equal("2", req.NextSigner().SignatureID)
+	assert.Equal("2", req.SignerByEmail("b@example.com").SignatureID)
+	assert.Nil(req.SignerByEmail("nobody@example.com"))
+
+	for _, sig := range req.Signatures {
+		sig.StatusCode = StatusSigned
+	}
+	assert.Nil(req.NextSigner())
+}
@@ -0,0 +1,47 @@
+package hellosign
+
+import (
+	"bytes"
+	"strconv"
+	"time"
+)
+
+// Timestamp wraps time.Time and decodes HelloSign's unix second timestamps.
+// Nullable timestamps are declared as *Timestamp and decode to nil when null.
+type Timestamp struct {
+	time.Time
+}
+
+// NewTimestamp returns a Timestamp for the given unix seconds.
+func NewTimestamp(unix int64) Timestamp {
+	return Timestamp{time.Unix(unix, 0)}
+}
+
+// UnmarshalJSON accepts unix seconds as a number or quoted string, or null.
+func (t *Timestamp) UnmarshalJSON(data []byte) error {
+	data = bytes.Trim(data, `"`)
+	if len(data) == 0 || string(data) == "null" {
+		t.Time = time.Time{}
+		return nil
+	}
+
+	seconds, err := strconv.ParseInt(string(data), 10, 64)
+	if err != nil {
+		return err
+	}
+	t.Time = time.Unix(seconds, 0)
+	return nil
+}
+
+// MarshalJSON encodes the timestamp as unix seconds, or null when unset.
+func (t Timestamp) MarshalJSON() ([]byte, error) {
+	if t.IsZero() {
+		return []byte("null"), nil
+	}
+	return []byte(strconv.FormatInt(t.Unix(), 10)), nil
+}
+
+// Expired reports whether the sign URL is past its expiry time.
+func (s *SignURLResponse) Expired() bool {
+	return !s.ExpiresAt.IsZero() && !time.Now().Before(s.ExpiresAt.Time)
+}
@@ -0,0 +1,53 @@
+package hellosign
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTimestampUnmarshal(t *testing.T) {
+	assert := assert.New(t)
+
+	sig := &Signature{}
+	err := json.Unmarshal([]byte(`{"signed_at":1504733583,"last_viewed_at":null,"last_reminded_at":"1504734014"}`), sig)
+
+	assert.Nil(err, "Should not return error")
+	assert.NotNil(sig.SignedAt)
+	assert.Equal(int64(1504733583), sig.SignedAt.Unix())
+	assert.Nil(sig.LastViewedAt)
+	assert.Equal(int64(1504734014), sig.LastRemindedAt.Unix())
+
+	req := &SignatureRequest{}
+	err = json.Unmarshal([]byte(`{"created_at":null}`), req)
+	assert.Nil(err, "Should not return error")
+	assert.True(req.CreatedAt.IsZero())
+
+	err = json.Unmarshal([]byte(`{"created_at":"yesterday"}`), req)
+	assert.NotNil(err, "Should return error")
+}
+
+func TestTimestampMarshal(t *testing.T) {
+	assert := assert.New(t)
+
+	data, err := json.Marshal(struct {
+		Set   Timestamp  `json:"set"`
+		Unset Timestamp  `json:"unset"`
+		Nil   *Timestamp `json:"nil"`
+	}{Set: NewTimestamp(1505259198)})
+
+	assert.Nil(err, "Should not return error")
+	assert.Equal(`{"set":1505259198,"unset":null,"nil":null}`, string(data))
+}
+
+func TestSignURLResponseExpired(t *testing.T) {
+	assert := assert.New(t)
+
+	res := &SignURLResponse{ExpiresAt: Timestamp{time.Now().Add(time.Hour)}}
+	assert.False(res.Expired())
+
+	res.ExpiresAt = Timestamp{time.Now().Add(-time.Minute)}
+	assert.True(res.Expired())
+}
@@ -0,0 +1,167 @@
+package hellosign
+
+import (
+	"fmt"
+	"net/mail"
+	"sort"
+	"strings"
+	"unicode/utf8"
+)
+
+// Limits enforced by HelloSign on signature request parameters.
+const (
+	MaxMetadataKeys        = 10
+	MaxMetadataKeyLength   = 40
+	MaxMetadataValueLength = 500
+	MaxTitleLength         = 255
+	MaxSubjectLength       = 255
+	MaxMessageLength       = 5000
+)
+
+// ValidationError lists every problem found in a request before it is sent.
+type ValidationError struct {
+	Errors []error
+}
+
+func (e *ValidationError) Error() string {
+	messages := make([]string, 0, len(e.Errors))
+	for _, err := range e.Errors {
+		messages = append(messages, err.Error())
+	}
+	return "invalid request: " + strings.Join(messages, "; ")
+}
+
+func (e *ValidationError) add(format string, args ...interface{}) {
+	e.Errors = append(e.Errors, fmt.Errorf(format, args...))
+}
+
+// Validate - Checks the request against HelloSign's rules without sending it.
+// It returns a *ValidationError describing every problem, or nil.
+func (r CreationRequest) Validate() error {
+	v := &ValidationError{}
+
+	r.validateSigners(v)
+	r.validateDocuments(v)
+	r.validateMetadata(v)
+
+	for i, field := range r.CustomFields {
+		if err := field.validate(); err != nil {
+			v.add("custom_fields[%d]: %v", i, err)
+		}
+	}
+
+	for i, attachment := range r.Attachments {
+		if attachment.SignerIndex < 0 || attachment.SignerIndex >= len(r.Signers) {
+			v.add("attachments[%d]: signer_index %d is out of range", i, attachment.SignerIndex)
+		}
+	}
+
+	checkLength(v, "title", r.Title, MaxTitleLength)
+	checkLength(v, "subject", r.Subject, MaxSubjectLength)
+	checkLength(v, "message", r.Message, MaxMessageLength)
+
+	if len(v.Errors) > 0 {
+		return v
+	}
+	return nil
+}
+
+func (r CreationRequest) validateSigners(v *ValidationError) {
+	if len(r.Signers) == 0 {
+		v.add("signers: at least one signer is required")
+	}
+
+	for i, signer := range r.Signers {
+		name := fmt.Sprintf("signers[%d]", i)
+		if len(signer.GroupMembers) > 0 {
+			if signer.Group == "" {
+				v.add("%s: group name is required for group signers", name)
+			}
+			for j, member := range signer.GroupMembers {
+				validateSigner(v, fmt.Sprintf("%s[%d]", name, j), member)
+			}
+			continue
+		}
+		validateSigner(v, name, signer)
+	}
+}
+
+func validateSigner(v *ValidationError, name string, signer Signer) {
+	if len(signer.GroupMembers) > 0 {
+		v.add("%s: signer groups cannot be nested", name)
+	}
+	if strings.TrimSpace(signer.Name) == "" {
+		v.add("%s: name is required", name)
+	}
+	if signer.Email == "" {
+		v.add("%s: email_address is required", name)
+	} else if _, err := mail.ParseAddress(signer.Email); err != nil {
+		v.add("%s: invalid email_address %q", name, signer.Email)
+	}
+	if err := signer.validateOptions(name); err != nil {
+		v.Errors = append(v.Errors, err)
+	}
+}
+
+func (r CreationRequest) validateDocuments(v *ValidationError) {
+	documents := len(r.File) + len(r.FileURL)
+	switch {
+	case len(r.TemplateID) > 0:
+		v.add("template_ids: sending from templates is not supported; use file or file_url")
+	case len(r.File) > 0 && len(r.FileURL) > 0:
+		v.add("file and file_url cannot be used together")
+	case documents == 0:
+		v.add("file or file_url is required")
+	}
+
+	if len(r.FormFieldsPerDocument) > 0 && documents > 0 && len(r.FormFieldsPerDocument) != documents {
+		v.add("form_fields_per_document: expected %d documents, got %d", documents, len(r.FormFieldsPerDocument))
+	}
+
+	signers := len(r.Signers)
+	seen := map[string]bool{}
+	for i, fields := range r.FormFieldsPerDocument {
+		for j, field := range fields {
+			if field.Signer < 0 || (signers > 0 && field.Signer >= signers) {
+				v.add("form_fields_per_document[%d][%d]: signer %d is out of range", i, j, field.Signer)
+			}
+			if field.APIId != "" && seen[field.APIId] {
+				v.add("form_fields_per_document[%d][%d]: duplicate api_id %q", i, j, field.APIId)
+			}
+			seen[field.APIId] = true
+		}
+	}
+
+	if err := r.validateFormFields(); err != nil {
+		v.Errors = append(v.Errors, err)
+	}
+}
+
+func (r CreationRequest) validateMetadata(v *ValidationError) {
+	if len(r.Metadata) > MaxMetadataKeys {
+		v.add("metadata: at most %d keys are allowed, got %d", MaxMetadataKeys, len(r.Metadata))
+	}
+	for _, key := range sortedKeys(r.Metadata) {
+		if utf8.RuneCountInString(key) > MaxMetadataKeyLength {
+			v.add("metadata[%s]: key is longer than %d characters", key, MaxMetadataKeyLength)
+		}
+		if utf8.RuneCountInString(r.Metadata[key]) > MaxMetadataValueLength {
+			v.add("metadata[%s]: value is longer than %d characters", key, MaxMetadataValueLength)
+		}
+	}
+}
+
+func checkLength(v *ValidationError, name, value string, max int) {
+	if utf8.RuneCountInString(value) > max {
+		v.add("%s: longer than %d characters", name, max)
+	}
+}
+
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,76 @@
+package hellosign
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCreationRequestValidateSuccess(t *testing.T) {
+	assert.Nil(t, creationRequest().Validate())
+}
+
+func TestCreationRequestValidateCollectsErrors(t *testing.T) {
+	assert := assert.New(t)
+
+	request := creationRequest()
+	request.FileURL = []string{"http://www.pdf995.com/samples/pdf.pdf"}
+	request.Signers[0].Name = ""
+	request.Signers[1].Email = "not an email"
+	request.Attachments = []Attachment{{Name: "ID", SignerIndex: 2}}
+	request.FormFieldsPerDocument[1][0].APIId = "api_id"
+	request.FormFieldsPerDocument[1][0].Signer = 5
+	request.Subject = strings.Repeat("s", MaxSubjectLength+1)
+	request.Metadata = map[string]string{}
+	for i := 0; i < MaxMetadataKeys; i++ {
+		request.Metadata[fmt.Sprintf("key%d", i)] = "value"
+	}
+	request.Metadata[strings.Repeat("k", MaxMetadataKeyLength+1)] = strings.Repeat("v", MaxMetadataValueLength+1)
+
+	err := request.Validate()
+	if !assert.IsType(&ValidationError{}, err) {
+		return
+	}
+
+	messages := []string{}
+	for _, e := range err.(*ValidationError).Errors {
+		messages = append(messages, e.Error())
+	}
+
+	assert.Equal([]string{
+		"signers[0]: name is required",
+		`signers[1]: invalid email_address "not an email"`,
+		"file and file_url cannot be used together",
+		"form_fields_per_document: expected 3 documents, got 2",
+		"form_fields_per_document[1][0]: signer 5 is out of range",
+		`form_fields_per_document[1][0]: duplicate api_id "api_id"`,
+		"metadata: at most 10 keys are allowed, got 11",
+		"metadata[kkkkkkkkkkkkkkkkkkkkkkkkkkkkkkkkkkkkkkkkk]: key is longer than 40 characters",
+		"metadata[kkkkkkkkkkkkkkkkkkkkkkkkkkkkkkkkkkkkkkkkk]: value is longer than 500 characters",
+		"attachments[0]: signer_index 2 is out of range",
+		"subject: longer than 255 characters",
+	}, messages)
+}
+
+func TestCreationRequestValidateGroupSigners(t *testing.T) {
+	assert := assert.New(t)
+
+	request := CreationRequest{
+		FileURL: []string{"http://www.pdf995.com/samples/pdf.pdf"},
+		Signers: []Signer{
+			{GroupMembers: []Signer{{Name: "Alice", Email: "a@example.com"}, {Email: "b@example.com"}}},
+		},
+	}
+
+	err := request.Validate()
+	assert.Equal("invalid request: signers[0]: group name is required for group signers; signers[0][1]: name is required", err.Error())
+}
+
+func TestCreationRequestValidateTemplate(t *testing.T) {
+	request := CreationRequest{TemplateID: []string{"c26b8a16784a872da37ea946b9ddec7c1e11dff6"}}
+
+	err := request.Validate()
+	assert.Equal(t, "invalid request: signers: at least one signer is required; template_ids: sending from templates is not supported; use file or file_url", err.Error())
+}